@@ -0,0 +1,230 @@
+package smetana
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// A [Node] whose content is computed concurrently with the rest of the page,
+// such as a section that depends on a slow database query or API call.
+// Create an [AsyncNode] with [Async]:
+//
+//	Div(
+//		H1("Dashboard"),
+//		Async(func(ctx context.Context) (Node, error) {
+//			orders, err := fetchOrders(ctx)
+//			if err != nil {
+//				return nil, err
+//			}
+//			return OrderTable(orders), nil
+//		}).WithFallback(P("Orders are unavailable")),
+//	)
+//
+// When rendered with [RenderHtmlContext], each [AsyncNode] is computed in its
+// own goroutine while the rest of the tree renders, and the results are
+// stitched into the output in document order. If the function returns an
+// error, or the context is cancelled before it finishes, then the `Fallback`
+// is rendered instead (if it isn't nil) and the error is returned from the
+// render function.
+//
+// When rendered with other render functions such as [RenderHtml], the
+// function is called synchronously and errors are reported with
+// [Builder.ReportError].
+type AsyncNode struct {
+	Render   func(ctx context.Context) (Node, error)
+	Fallback Node
+	Timeout  time.Duration
+}
+
+// Create an [AsyncNode] from a function that computes its content.
+func Async(render func(ctx context.Context) (Node, error)) AsyncNode {
+	return AsyncNode{render, nil, 0}
+}
+
+// Set a [Node] to be rendered if an [AsyncNode] fails.
+func (node AsyncNode) WithFallback(fallback Node) AsyncNode {
+	node.Fallback = fallback
+	return node
+}
+
+// Set a timeout for computing the content of an [AsyncNode], after which
+// the context passed to its function is cancelled.
+func (node AsyncNode) WithTimeout(timeout time.Duration) AsyncNode {
+	node.Timeout = timeout
+	return node
+}
+
+// Call the function of an [AsyncNode] with the given context, applying the
+// timeout if there is one.
+func (node AsyncNode) compute(ctx context.Context) (Node, error) {
+	if node.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, node.Timeout)
+		defer cancel()
+	}
+	return node.Render(ctx)
+}
+
+// Convert an [AsyncNode] to HTML. If the [Builder] supports concurrent
+// rendering then the content is computed in a new goroutine and a segment is
+// reserved for it in the output, otherwise it is computed immediately.
+func (node AsyncNode) ToHtml(b *Builder) {
+	if b.async == nil {
+		content, err := node.compute(b.Context())
+		if err != nil {
+			b.ReportError(err)
+			content = node.Fallback
+		}
+		if content != nil {
+			content.ToHtml(b)
+		}
+		return
+	}
+
+	segment := asyncSegment{
+		prefix:   b.Buf.String(),
+		fallback: node.Fallback,
+		result:   make(chan asyncResult, 1),
+	}
+	b.Buf.Reset()
+	b.async.segments = append(b.async.segments, segment)
+	renderer := b.async.renderer
+	parent := renderer.newBuilder(b)
+	go func() {
+		segment.result <- renderer.run(node, &parent)
+	}()
+}
+
+// Shared state for a single call to [RenderHtmlContext].
+type asyncRenderer struct {
+	ctx   context.Context
+	slots chan struct{}
+}
+
+// The output of a [Builder] that supports concurrent rendering is split into
+// segments, each containing some static HTML followed by the result of an
+// [AsyncNode].
+type asyncState struct {
+	renderer *asyncRenderer
+	segments []asyncSegment
+}
+
+type asyncSegment struct {
+	prefix   string
+	fallback Node
+	result   chan asyncResult
+}
+
+type asyncResult struct {
+	html string
+	err  error
+}
+
+// Compute and render the content of an [AsyncNode]. A concurrency slot is
+// only held while the node's function is running, so that nested nodes
+// can't deadlock waiting for their parents to finish. As this runs in a
+// separate goroutine, panics are recovered and returned as errors.
+func (renderer *asyncRenderer) run(
+	node AsyncNode,
+	parent *Builder,
+) (result asyncResult) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			result.err = RenderError{
+				parent.pathString(),
+				fmt.Errorf("panic: %v", recovered),
+			}
+			result.html = ""
+			if node.Fallback != nil {
+				result.html, _ = parent.renderNode(node.Fallback)
+			}
+		}
+	}()
+	ctx := parent.Context()
+	var content Node
+	var err error
+	if renderer.slots != nil {
+		select {
+		case renderer.slots <- struct{}{}:
+			func() {
+				defer func() { <-renderer.slots }()
+				content, err = node.compute(ctx)
+			}()
+		case <-ctx.Done():
+			err = ctx.Err()
+		}
+	} else {
+		content, err = node.compute(ctx)
+	}
+	if err != nil {
+		content = node.Fallback
+	}
+
+	builder := renderer.newBuilder(parent)
+	if content != nil {
+		content.ToHtml(&builder)
+	}
+	html, renderErr := builder.collectAsync()
+	if err == nil {
+		err = renderErr
+	}
+	if err == nil {
+		err = builder.boundaryError()
+	}
+	return asyncResult{html, err}
+}
+
+// Create a new [Builder] with the same settings as the parent that supports
+// concurrent rendering. If the parent is inside an [ErrorBoundaryNode] then
+// the new [Builder] collects reported errors in its own boundary state, so
+// that they can be returned with the result instead of being logged.
+func (renderer *asyncRenderer) newBuilder(parent *Builder) Builder {
+	builder := Builder{
+		DeterministicAttributes: parent.DeterministicAttributes,
+		Logger:                  parent.Logger,
+		ctx:                     parent.ctx,
+		async:                   &asyncState{renderer: renderer},
+		path:                    append([]string{}, parent.path...),
+	}
+	if parent.boundary != nil {
+		builder.boundary = &boundaryState{}
+	}
+	return builder
+}
+
+// Wait for all of the [AsyncNode]s in a [Builder] to finish, and combine
+// their output in document order. The first error in document order is
+// returned. If the context is cancelled then the fallbacks are rendered for
+// any nodes that haven't finished.
+func (builder *Builder) collectAsync() (string, error) {
+	if builder.async == nil || len(builder.async.segments) == 0 {
+		return builder.Buf.String(), nil
+	}
+	ctx := builder.async.renderer.ctx
+	var err error
+	var out strings.Builder
+	for _, segment := range builder.async.segments {
+		out.WriteString(segment.prefix)
+		var result asyncResult
+		select {
+		case result = <-segment.result:
+		default:
+			select {
+			case result = <-segment.result:
+			case <-ctx.Done():
+				result.err = ctx.Err()
+				if segment.fallback != nil {
+					result.html, _ = builder.renderNode(segment.fallback)
+				}
+			}
+		}
+		if err == nil {
+			err = result.err
+		}
+		out.WriteString(result.html)
+	}
+	out.WriteString(builder.Buf.String())
+	return out.String(), err
+}
@@ -0,0 +1,176 @@
+package smetana
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func asyncText(text string, delay time.Duration) AsyncNode {
+	return Async(func(ctx context.Context) (Node, error) {
+		select {
+		case <-time.After(delay):
+			return Text(text), nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	})
+}
+
+func TestAsyncNodesAreStitchedInDocumentOrder(t *testing.T) {
+	node := Div(
+		P(asyncText("a", 30*time.Millisecond)),
+		P("b"),
+		P(asyncText("c", 10*time.Millisecond)),
+		asyncText("d", 0),
+	)
+	result, err := RenderHtmlContext(context.Background(), node)
+	assertEqual(t, nil, err)
+	assertEqual(t, "<div><p>a</p><p>b</p><p>c</p>d</div>", result)
+}
+
+func TestAsyncNodesRenderConcurrently(t *testing.T) {
+	children := Children{}
+	for i := 0; i < 10; i++ {
+		children = append(children, asyncText("x", 50*time.Millisecond))
+	}
+	start := time.Now()
+	result, err := RenderHtmlContext(context.Background(), Div(children))
+	assertEqual(t, nil, err)
+	assertEqual(t, "<div>xxxxxxxxxx</div>", result)
+	assertEqual(t, true, time.Since(start) < 400*time.Millisecond)
+}
+
+func TestNestedAsyncNodes(t *testing.T) {
+	node := Async(func(ctx context.Context) (Node, error) {
+		deep := Async(func(ctx context.Context) (Node, error) {
+			return Span(asyncText("deep", 0)), nil
+		})
+		return Div(asyncText("inner", 0), deep), nil
+	})
+	result, err := RenderHtmlContextOpts(context.Background(), node, 1, false, nil)
+	assertEqual(t, nil, err)
+	assertEqual(t, "<div>inner<span>deep</span></div>", result)
+}
+
+func TestAsyncConcurrencyIsBounded(t *testing.T) {
+	var running int32
+	var maxRunning int32
+	children := Children{}
+	for i := 0; i < 8; i++ {
+		children = append(children, Async(func(ctx context.Context) (Node, error) {
+			current := atomic.AddInt32(&running, 1)
+			for {
+				previous := atomic.LoadInt32(&maxRunning)
+				if current <= previous ||
+					atomic.CompareAndSwapInt32(&maxRunning, previous, current) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			atomic.AddInt32(&running, -1)
+			return Text("."), nil
+		}))
+	}
+	result, err := RenderHtmlContextOpts(
+		context.Background(),
+		Div(children),
+		2,
+		false,
+		nil,
+	)
+	assertEqual(t, nil, err)
+	assertEqual(t, "<div>........</div>", result)
+	assertEqual(t, true, atomic.LoadInt32(&maxRunning) <= 2)
+}
+
+func TestAsyncErrorsArePropagated(t *testing.T) {
+	first := errors.New("first")
+	second := errors.New("second")
+	node := Div(
+		Async(func(ctx context.Context) (Node, error) {
+			time.Sleep(20 * time.Millisecond)
+			return nil, first
+		}).WithFallback(Text("fallback")),
+		Async(func(ctx context.Context) (Node, error) {
+			return nil, second
+		}),
+		P("after"),
+	)
+	result, err := RenderHtmlContext(context.Background(), node)
+	assertEqual(t, first, err)
+	assertEqual(t, "<div>fallback<p>after</p></div>", result)
+}
+
+func TestAsyncContextCancellation(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	node := Div(
+		asyncText("fast", 0),
+		asyncText("slow", time.Minute).WithFallback(Text("loading failed")),
+	)
+	result, err := RenderHtmlContext(ctx, node)
+	assertEqual(t, context.DeadlineExceeded, err)
+	assertEqual(t, "<div>fastloading failed</div>", result)
+}
+
+func TestAsyncContextCancellationWhileWaitingForConcurrency(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	var calls int32
+	released := make(chan struct{})
+	node := func() AsyncNode {
+		return Async(func(ctx context.Context) (Node, error) {
+			// Hold the only concurrency slot until after the deadline
+			atomic.AddInt32(&calls, 1)
+			<-ctx.Done()
+			time.Sleep(20 * time.Millisecond)
+			close(released)
+			return nil, ctx.Err()
+		}).WithFallback(Text("cancelled"))
+	}
+	result, err := RenderHtmlContextOpts(ctx, Div(node(), node()), 1, false, nil)
+	assertEqual(t, context.DeadlineExceeded, err)
+	assertEqual(t, "<div>cancelledcancelled</div>", result)
+	<-released
+	assertEqual(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestAsyncNodeTimeout(t *testing.T) {
+	node := asyncText("slow", time.Minute).
+		WithTimeout(10 * time.Millisecond).
+		WithFallback(Text("timed out"))
+	result, err := RenderHtmlContext(context.Background(), node)
+	assertEqual(t, context.DeadlineExceeded, err)
+	assertEqual(t, "timed out", result)
+}
+
+func TestAsyncNodesRenderSynchronouslyWithoutContext(t *testing.T) {
+	node := Div(asyncText("a", 0), Async(func(ctx context.Context) (Node, error) {
+		return nil, errors.New("failed")
+	}).WithFallback(Text("b")))
+	var target strings.Builder
+	logger := log.New(&target, "", 0)
+	assertEqual(t, "<div>ab</div>", RenderHtmlOpts(node, false, logger))
+	assertEqual(t, "failed\n", target.String())
+}
+
+func TestHandlerLogsAsyncErrors(t *testing.T) {
+	var target strings.Builder
+	handler := newTestHandler()
+	handler.Logger = log.New(&target, "", 0)
+	handler.Page("/async", func(r *http.Request) Node {
+		return Async(func(ctx context.Context) (Node, error) {
+			return nil, errors.New("failed")
+		}).WithFallback(Text("fallback"))
+	})
+	response := serveTestRequest(handler, httptest.NewRequest("GET", "/async", nil))
+	assertEqual(t, "fallback", readTestBody(t, response))
+	assertEqual(t, "failed\n", target.String())
+}
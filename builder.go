@@ -1,7 +1,12 @@
 package smetana
 
 import (
+	"context"
+	"errors"
+	"io"
 	"log"
+	"net/http"
+	"os"
 	"sort"
 	"strings"
 )
@@ -10,14 +15,116 @@ import (
 //   - `Buf` is the string buffer being written to.
 //   - By default, the order of HTML tag attributes is undefined and
 //     non-deterministic. It can be changed to be deterministic by
-//     setting `deterministicAttributes` to true. Note that this has
+//     setting `DeterministicAttributes` to true. Note that this has
 //     a significant performance cost.
-//   - `logger` is used for reporting warnings and errors during
+//   - `Logger` is used for reporting warnings and errors during
 //     compilation.
+//
+// The [Builder] also has unexported fields for rendering state, so it must be
+// created with [NewBuilder] or a struct literal with field names (ie;
+// `Builder{Logger: logger}`) rather than a positional struct literal.
+//
+// When rendering with [RenderHtmlStream], the contents of `Buf` can be sent
+// to the client mid-render with [Builder.Flush].
+//
+// The [Builder] also carries a [context.Context] which can be read by custom
+// nodes with [Builder.Context]. Request-scoped values can be added to it for
+// a subtree with a [ProviderNode] and read with a [ContextKey].
 type Builder struct {
 	Buf                     strings.Builder
 	DeterministicAttributes bool
 	Logger                  *log.Logger
+	ctx                     context.Context
+	async                   *asyncState
+	stream                  *streamState
+	boundary                *boundaryState
+	path                    []string
+}
+
+// Create a new [Builder] with the given settings. If `logger` is nil then
+// warnings and errors are logged to stderr.
+func NewBuilder(deterministicAttrs bool, logger *log.Logger) *Builder {
+	if logger == nil {
+		logger = log.New(os.Stderr, "", 0)
+	}
+	return &Builder{DeterministicAttributes: deterministicAttrs, Logger: logger}
+}
+
+// Report an error that occurred while rendering a node. Inside an
+// [ErrorBoundaryNode] this causes the fallback to be rendered instead of the
+// child, otherwise the error is logged with the `Logger`.
+func (builder *Builder) ReportError(err error) {
+	if builder.boundary != nil {
+		builder.boundary.errors = append(
+			builder.boundary.errors,
+			RenderError{builder.pathString(), err},
+		)
+	} else {
+		builder.Logger.Println(err)
+	}
+}
+
+// Get the path of the node currently being rendered, such as
+// "html > body > div".
+func (builder *Builder) pathString() string {
+	return strings.Join(builder.path, " > ")
+}
+
+// Get the [context.Context] for the current position in the tree. This is
+// the context passed to render functions such as [RenderHtmlContext] along
+// with any values added by [ProviderNode]s, or [context.Background] if
+// there is no context.
+func (builder *Builder) Context() context.Context {
+	if builder.ctx == nil {
+		return context.Background()
+	}
+	return builder.ctx
+}
+
+// Write the output so far to the stream being rendered to, and flush it to
+// the client if the stream is an [http.Flusher]. Any [AsyncNode]s that have
+// already been rendered are waited for first, and the first error from them
+// is returned. If the [Builder] isn't streaming (ie; it wasn't created by
+// [RenderHtmlStream]), or it's rendering part of the page separately (such as
+// the child of an [ErrorBoundaryNode]), then this does nothing.
+func (builder *Builder) Flush() error {
+	if builder.stream == nil || builder.stream.root != builder {
+		return nil
+	}
+	html, err := builder.collectAsync()
+	builder.Buf.Reset()
+	builder.async.segments = nil
+	_, writeErr := io.WriteString(builder.stream.writer, html)
+	if writeErr != nil {
+		return writeErr
+	}
+	if flusher, ok := builder.stream.writer.(http.Flusher); ok {
+		flusher.Flush()
+	}
+	return err
+}
+
+// Render a [Node] to HTML using the same settings as the given [Builder].
+// Errors reported while rendering are passed on to the [Builder] (so that
+// they're caught by any enclosing [ErrorBoundaryNode]), and the returned bool
+// is false if there were any so that callers can avoid caching broken output.
+func (builder *Builder) renderNode(node Node) (string, bool) {
+	scratch := Builder{
+		DeterministicAttributes: builder.DeterministicAttributes,
+		Logger:                  builder.Logger,
+		ctx:                     builder.ctx,
+		boundary:                &boundaryState{},
+		path:                    append([]string{}, builder.path...),
+	}
+	node.ToHtml(&scratch)
+	for _, err := range scratch.boundary.errors {
+		if builder.boundary != nil {
+			builder.boundary.errors = append(builder.boundary.errors, err)
+		} else {
+			builder.Logger.Println(errors.Unwrap(err))
+		}
+	}
+	return scratch.Buf.String(), len(scratch.boundary.errors) == 0
 }
 
 func (builder *Builder) writeAttr(key string, value string) {
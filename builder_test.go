@@ -12,7 +12,7 @@ func TestWriteOpeningTag(t *testing.T) {
 		"foo":   "bar",
 		"hello": "world",
 	}
-	builder := Builder{strings.Builder{}, true, nil}
+	builder := Builder{DeterministicAttributes: true}
 	builder.writeOpeningTag(tag, attrs)
 	result := builder.Buf.String()
 	assertEqual(t, "<div foo=\"bar\" hello=\"world\">", result)
@@ -24,7 +24,7 @@ func TestWriteOpeningTagWithNonDeterministicAttributes(t *testing.T) {
 		"foo":   "bar",
 		"hello": "world",
 	}
-	builder := Builder{strings.Builder{}, false, nil}
+	builder := Builder{}
 	builder.writeOpeningTag(tag, attrs)
 	result := builder.Buf.String()
 	if result[5] == 'f' {
@@ -36,7 +36,7 @@ func TestWriteOpeningTagWithNonDeterministicAttributes(t *testing.T) {
 
 func TestWriteClosingTag(t *testing.T) {
 	tag := "span"
-	builder := Builder{strings.Builder{}, true, nil}
+	builder := Builder{DeterministicAttributes: true}
 	builder.writeClosingTag(tag)
 	result := builder.Buf.String()
 	assertEqual(t, "</span>", result)
@@ -45,8 +45,18 @@ func TestWriteClosingTag(t *testing.T) {
 func TestCustomLogger(t *testing.T) {
 	var target strings.Builder
 	logger := log.New(&target, "", 0)
-	builder := Builder{strings.Builder{}, true, logger}
+	builder := Builder{DeterministicAttributes: true, Logger: logger}
 	builder.Logger.Print("Hello world")
 	result := strings.TrimSpace(target.String())
 	assertEqual(t, "Hello world", result)
 }
+
+func TestNewBuilder(t *testing.T) {
+	var target strings.Builder
+	logger := log.New(&target, "", 0)
+	builder := NewBuilder(true, logger)
+	Div(Attrs{"b": "2", "a": "1"}).ToHtml(builder)
+	assertEqual(t, "<div a=\"1\" b=\"2\"></div>", builder.Buf.String())
+	assertEqual(t, logger, builder.Logger)
+	assertNotEqual(t, nil, NewBuilder(false, nil).Logger)
+}
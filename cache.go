@@ -0,0 +1,225 @@
+package smetana
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// Interface for caches used to store rendered HTML for [CachedNode]s. The
+// default implementation is [LruCache], but any other storage can be used by
+// implementing this interface. Implementations must be safe for concurrent
+// use.
+//   - `Get` returns the HTML for a key and whether it was found.
+//   - `Set` stores the HTML for a key. If `ttl` is positive then the entry
+//     expires after that duration. The entry can be invalidated later by
+//     any of the given tags.
+//   - `Invalidate` removes the entry for a single key.
+//   - `InvalidateTag` removes all entries with the given tag.
+type RenderCache interface {
+	Get(key string) ([]byte, bool)
+	Set(key string, html []byte, ttl time.Duration, tags []string)
+	Invalidate(key string)
+	InvalidateTag(tag string)
+}
+
+type lruCacheEntry struct {
+	key     string
+	html    []byte
+	expires time.Time
+	tags    []string
+}
+
+// An in-memory [RenderCache] that holds up to a fixed number of entries,
+// evicting the least recently used entry when it is full. Create an
+// [LruCache] with [NewLruCache].
+type LruCache struct {
+	capacity int
+	mutex    sync.Mutex
+	entries  map[string]*list.Element
+	order    *list.List
+	tags     map[string]map[string]bool
+	now      func() time.Time
+}
+
+// Create a new [LruCache] that holds up to `capacity` entries.
+func NewLruCache(capacity int) *LruCache {
+	return &LruCache{
+		capacity: capacity,
+		entries:  map[string]*list.Element{},
+		order:    list.New(),
+		tags:     map[string]map[string]bool{},
+		now:      time.Now,
+	}
+}
+
+// The [RenderCache] used by [CachedNode]s that don't specify a cache.
+var DefaultRenderCache RenderCache = NewLruCache(1024)
+
+// Get the HTML for a key from an [LruCache] and whether it was found.
+// Expired entries are removed and are not returned.
+func (cache *LruCache) Get(key string) ([]byte, bool) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	element, ok := cache.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := element.Value.(*lruCacheEntry)
+	if !entry.expires.IsZero() && !cache.now().Before(entry.expires) {
+		cache.remove(element)
+		return nil, false
+	}
+	cache.order.MoveToFront(element)
+	return entry.html, true
+}
+
+// Store the HTML for a key in an [LruCache], evicting the least recently used
+// entry if the cache is full.
+func (cache *LruCache) Set(
+	key string,
+	html []byte,
+	ttl time.Duration,
+	tags []string,
+) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	if element, ok := cache.entries[key]; ok {
+		cache.remove(element)
+	}
+	entry := &lruCacheEntry{key, html, time.Time{}, tags}
+	if ttl > 0 {
+		entry.expires = cache.now().Add(ttl)
+	}
+	cache.entries[key] = cache.order.PushFront(entry)
+	for _, tag := range tags {
+		if cache.tags[tag] == nil {
+			cache.tags[tag] = map[string]bool{}
+		}
+		cache.tags[tag][key] = true
+	}
+	for cache.capacity > 0 && cache.order.Len() > cache.capacity {
+		cache.remove(cache.order.Back())
+	}
+}
+
+// Remove the entry for a key from an [LruCache].
+func (cache *LruCache) Invalidate(key string) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	if element, ok := cache.entries[key]; ok {
+		cache.remove(element)
+	}
+}
+
+// Remove all entries with the given tag from an [LruCache].
+func (cache *LruCache) InvalidateTag(tag string) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	for key := range cache.tags[tag] {
+		if element, ok := cache.entries[key]; ok {
+			cache.remove(element)
+		}
+	}
+	delete(cache.tags, tag)
+}
+
+// Get the number of entries in an [LruCache], including expired entries that
+// haven't been removed yet.
+func (cache *LruCache) Len() int {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	return cache.order.Len()
+}
+
+// Remove an entry. The mutex must already be held.
+func (cache *LruCache) remove(element *list.Element) {
+	entry := cache.order.Remove(element).(*lruCacheEntry)
+	delete(cache.entries, entry.key)
+	for _, tag := range entry.tags {
+		delete(cache.tags[tag], entry.key)
+		if len(cache.tags[tag]) == 0 {
+			delete(cache.tags, tag)
+		}
+	}
+}
+
+// A [Node] that memoizes the rendered HTML of a subtree in a [RenderCache] so
+// that expensive parts of a page, such as navigation menus and footers, are
+// only rendered once across requests. Create a [CachedNode] with [Cached]:
+//
+//	Cached("footer", time.Hour, func() Node {
+//		return Footer(expensiveLinks())
+//	}).WithTags("navigation")
+//
+// The render function is only called on a cache miss, and the HTML isn't
+// cached if any errors are reported while rendering it (for example, so that
+// the fallback of an [ErrorBoundaryNode] is used). The cached HTML can be
+// removed with `Invalidate` or `InvalidateTag` on the cache (which is
+// [DefaultRenderCache] unless `Cache` is set).
+//
+// As the cached HTML is shared between requests, values from the [Builder]'s
+// context (such as those from [ContextKey.Provide] or [RenderHtmlContext])
+// are hidden while the render function's node is rendered, so that one
+// user's data can't be served to another. The context's cancellation is
+// still passed on. Request-specific content should be rendered outside of
+// the [CachedNode], or the values it depends on should be part of the `Key`
+// and provided inside the render function.
+type CachedNode struct {
+	Key    string
+	Ttl    time.Duration
+	Tags   []string
+	Render func() Node
+	Cache  RenderCache
+}
+
+// Create a [CachedNode] for the given key. If `ttl` is zero then the cached
+// HTML never expires.
+func Cached(key string, ttl time.Duration, render func() Node) CachedNode {
+	return CachedNode{key, ttl, nil, render, nil}
+}
+
+// Add tags to a [CachedNode], which can be used to invalidate groups of
+// cached nodes together.
+func (node CachedNode) WithTags(tags ...string) CachedNode {
+	node.Tags = append(append([]string{}, node.Tags...), tags...)
+	return node
+}
+
+// Use a specific [RenderCache] for a [CachedNode] instead of
+// [DefaultRenderCache].
+func (node CachedNode) WithCache(cache RenderCache) CachedNode {
+	node.Cache = cache
+	return node
+}
+
+// Convert a [CachedNode] to HTML, using the cached HTML if available.
+func (node CachedNode) ToHtml(b *Builder) {
+	cache := node.Cache
+	if cache == nil {
+		cache = DefaultRenderCache
+	}
+	html, ok := cache.Get(node.Key)
+	if !ok {
+		parent := b.ctx
+		b.ctx = cacheContext{b.Context()}
+		content, succeeded := b.renderNode(node.Render())
+		b.ctx = parent
+		html = []byte(content)
+		if succeeded {
+			cache.Set(node.Key, html, node.Ttl, node.Tags)
+		}
+	}
+	b.Buf.Write(html)
+}
+
+// A [context.Context] that keeps the deadline and cancellation of its parent
+// but hides its values, for rendering [CachedNode]s.
+type cacheContext struct {
+	context.Context
+}
+
+func (ctx cacheContext) Value(key any) any {
+	return nil
+}
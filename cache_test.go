@@ -0,0 +1,186 @@
+package smetana
+
+import (
+	"context"
+	"io"
+	"log"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCachedNodeOnlyRendersOnce(t *testing.T) {
+	cache := NewLruCache(10)
+	renders := 0
+	node := Cached("menu", 0, func() Node {
+		renders++
+		return Ul(Li("Home"), Li("About"))
+	}).WithCache(cache)
+	page := Div(node, node)
+	assertEqual(
+		t,
+		"<div><ul><li>Home</li><li>About</li></ul><ul><li>Home</li><li>About</li></ul></div>",
+		RenderHtml(page),
+	)
+	assertEqual(t, 1, renders)
+	RenderHtml(page)
+	assertEqual(t, 1, renders)
+}
+
+func TestCachedNodeUsesDefaultCache(t *testing.T) {
+	defer func(cache RenderCache) { DefaultRenderCache = cache }(DefaultRenderCache)
+	DefaultRenderCache = NewLruCache(10)
+	renders := 0
+	node := Cached("footer", 0, func() Node {
+		renders++
+		return Footer("Footer")
+	})
+	assertEqual(t, "<footer>Footer</footer>", RenderHtml(node))
+	assertEqual(t, "<footer>Footer</footer>", RenderHtml(node))
+	assertEqual(t, 1, renders)
+	DefaultRenderCache.Invalidate("footer")
+	RenderHtml(node)
+	assertEqual(t, 2, renders)
+}
+
+func TestLruCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	cache := NewLruCache(2)
+	cache.Set("a", []byte("a"), 0, nil)
+	cache.Set("b", []byte("b"), 0, nil)
+	_, ok := cache.Get("a")
+	assertEqual(t, true, ok)
+	cache.Set("c", []byte("c"), 0, nil)
+	assertEqual(t, 2, cache.Len())
+	_, ok = cache.Get("b")
+	assertEqual(t, false, ok)
+	html, ok := cache.Get("a")
+	assertEqual(t, true, ok)
+	assertEqual(t, "a", string(html))
+	html, ok = cache.Get("c")
+	assertEqual(t, true, ok)
+	assertEqual(t, "c", string(html))
+}
+
+func TestLruCacheEntriesExpire(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache := NewLruCache(10)
+	cache.now = func() time.Time { return now }
+	cache.Set("a", []byte("a"), time.Minute, nil)
+	cache.Set("b", []byte("b"), 0, nil)
+	now = now.Add(59 * time.Second)
+	_, ok := cache.Get("a")
+	assertEqual(t, true, ok)
+	now = now.Add(time.Second)
+	_, ok = cache.Get("a")
+	assertEqual(t, false, ok)
+	now = now.Add(time.Hour)
+	_, ok = cache.Get("b")
+	assertEqual(t, true, ok)
+	assertEqual(t, 1, cache.Len())
+}
+
+func TestLruCacheSetReplacesExistingEntries(t *testing.T) {
+	cache := NewLruCache(10)
+	cache.Set("a", []byte("old"), 0, []string{"nav"})
+	cache.Set("a", []byte("new"), 0, []string{"footer"})
+	assertEqual(t, 1, cache.Len())
+	html, ok := cache.Get("a")
+	assertEqual(t, true, ok)
+	assertEqual(t, "new", string(html))
+	assertEqual(t, map[string]map[string]bool{"footer": {"a": true}}, cache.tags)
+}
+
+func TestLruCacheInvalidation(t *testing.T) {
+	cache := NewLruCache(10)
+	cache.Set("a", []byte("a"), 0, []string{"nav"})
+	cache.Set("b", []byte("b"), 0, []string{"nav", "footer"})
+	cache.Set("c", []byte("c"), 0, []string{"footer"})
+	cache.Set("d", []byte("d"), 0, nil)
+
+	cache.InvalidateTag("nav")
+	_, ok := cache.Get("a")
+	assertEqual(t, false, ok)
+	_, ok = cache.Get("b")
+	assertEqual(t, false, ok)
+	_, ok = cache.Get("c")
+	assertEqual(t, true, ok)
+	assertEqual(t, 2, cache.Len())
+
+	cache.Invalidate("d")
+	_, ok = cache.Get("d")
+	assertEqual(t, false, ok)
+	assertEqual(t, map[string]map[string]bool{"footer": {"c": true}}, cache.tags)
+}
+
+func TestCachedNodeTagsCanBeInvalidated(t *testing.T) {
+	cache := NewLruCache(10)
+	renders := 0
+	render := func() Node {
+		renders++
+		return Nav("Menu")
+	}
+	a := Cached("a", 0, render).WithTags("nav").WithCache(cache)
+	b := Cached("b", 0, render).WithTags("nav").WithCache(cache)
+	RenderHtml(Div(a, b))
+	assertEqual(t, 2, renders)
+	cache.InvalidateTag("nav")
+	RenderHtml(Div(a, b))
+	assertEqual(t, 4, renders)
+}
+
+func TestCachedNodeErrorsAreCaughtByErrorBoundaries(t *testing.T) {
+	var target strings.Builder
+	logger := log.New(&target, "", 0)
+	cache := NewLruCache(10)
+	node := ErrorBoundary(
+		Text("fallback"),
+		Cached("broken", 0, func() Node {
+			return Div(struct{}{})
+		}).WithCache(cache),
+	)
+	assertEqual(t, "fallback", RenderHtmlOpts(node, false, logger))
+	assertNotEqual(t, "", target.String())
+	assertEqual(t, 0, cache.Len())
+}
+
+func TestCachedNodeDoesNotCacheErrorsOutsideBoundaries(t *testing.T) {
+	var target strings.Builder
+	logger := log.New(&target, "", 0)
+	cache := NewLruCache(10)
+	node := Cached("broken", 0, func() Node {
+		return Div(struct{}{})
+	}).WithCache(cache)
+	assertEqual(t, "<div></div>", RenderHtmlOpts(node, false, logger))
+	assertEqual(t, "Invalid DomNode argument: {}\n", target.String())
+	assertEqual(t, 0, cache.Len())
+}
+
+func TestCachedNodeDoesNotSeeRequestContextValues(t *testing.T) {
+	cache := NewLruCache(10)
+	render := func(locale string) (string, error) {
+		node := Cached("locale", 0, func() Node {
+			return Div(localeNode{}, testLocale.Provide("de", localeNode{}))
+		}).WithCache(cache)
+		ctx := testLocale.WithValue(context.Background(), locale)
+		return RenderHtmlContext(ctx, testLocale.Provide(locale, node))
+	}
+	result, err := render("en")
+	assertEqual(t, nil, err)
+	assertEqual(t, "<div>nonede</div>", result)
+	result, err = render("fr")
+	assertEqual(t, nil, err)
+	assertEqual(t, "<div>nonede</div>", result)
+}
+
+func TestCachedNodeKeepsContextCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	node := Cached("cancelled", 0, func() Node {
+		return Async(func(ctx context.Context) (Node, error) {
+			return Text("content"), ctx.Err()
+		}).WithFallback(Text("cancelled"))
+	}).WithCache(NewLruCache(10))
+	result, err := RenderHtmlContextOpts(ctx, node, 0, false, log.New(io.Discard, "", 0))
+	assertEqual(t, nil, err)
+	assertEqual(t, "cancelled", result)
+}
@@ -2,6 +2,7 @@ package smetana
 
 import (
 	"fmt"
+	"math"
 	"strconv"
 )
 
@@ -98,20 +99,21 @@ func Rgba(r uint8, g uint8, b uint8, a uint8) RGBA {
 	return RGBA{r, g, b, a}
 }
 
-// Convert an [RGBA] into an [HSLA].
+// Convert an [RGBA] into an [HSLA]. The hue is not rounded, so converting
+// the result back with [HSLA.ToRgba] gives the original color.
 func (c RGBA) ToHsla() HSLA {
-	r := float32(c.R) / 255.0
-	g := float32(c.G) / 255.0
-	b := float32(c.B) / 255.0
+	r := float64(c.R) / 255.0
+	g := float64(c.G) / 255.0
+	b := float64(c.B) / 255.0
 	a := float32(c.A) / 255.0
 
 	l := max(max(r, g), b)
 	s := l - min(min(r, g), b)
 
-	var h float32
+	var h float64
 	if s > 0 {
 		if l == r {
-			h = (g - b) / s
+			h = math.Mod((g-b)/s+6, 6)
 		} else if l == g {
 			h = 2 + (b-r)/s
 		} else {
@@ -119,25 +121,18 @@ func (c RGBA) ToHsla() HSLA {
 		}
 	}
 
-	var H uint16
-	if 60*h < 0 {
-		H = uint16(60*h + 360)
-	} else {
-		H = uint16(60 * h)
-	}
+	L := l - s/2
 
-	var S float32
+	var S float64
 	if s > 0 {
-		if l <= 0.5 {
-			S = s / (2*l - s)
+		if L <= 0.5 {
+			S = s / (2 * L)
 		} else {
-			S = s / (2 - (2*l - s))
+			S = s / (2 - 2*L)
 		}
 	}
 
-	L := l - s/2
-
-	return HSLA{H, S, L, a}
+	return HSLA{float32(60 * h), float32(S), float32(L), a}
 }
 
 // Convert an [RGBA] into an [RGBA].
@@ -145,14 +140,14 @@ func (c RGBA) ToRgba() RGBA {
 	return c
 }
 
-// Structure representing an HSL [Color]. "H" is an unsigned value between
-// 0-360 inclusive representing a position on the color wheel. 0 is red, 120 is
+// Structure representing an HSL [Color]. "H" is a float between 0.0-360.0
+// representing a position on the color wheel in degrees. 0 is red, 120 is
 // green, 240 is blue, and other colors are interpolated between. S is
 // saturation and must be a float between 0.0-1.0 inclusive. L is the
 // lightness and must also be a float between 0.0-1.0 inclusive. Also see
 // [Hsl].
 type HSL struct {
-	H uint16
+	H float32
 	S float32
 	L float32
 }
@@ -161,11 +156,18 @@ type HSL struct {
 func (c HSL) String() string {
 	s := c.S * 100.0
 	l := c.L * 100.0
-	return fmt.Sprintf("hsl(%d, %.1f%%, %.1f%%)", c.H, s, l)
+	return fmt.Sprintf("hsl(%s, %.1f%%, %.1f%%)", formatHue(c.H), s, l)
+}
+
+// Format a hue in degrees for CSS with at most two decimal places, so that
+// whole numbers of degrees don't have a decimal point.
+func formatHue(hue float32) string {
+	rounded := math.Round(float64(hue)*100) / 100
+	return strconv.FormatFloat(rounded, 'f', -1, 64)
 }
 
 // Create an [HSL] color.
-func Hsl(h uint16, s float32, l float32) HSL {
+func Hsl(h float32, s float32, l float32) HSL {
 	return HSL{h, s, l}
 }
 
@@ -183,7 +185,7 @@ func (c HSL) ToRgba() RGBA {
 // more info. The alpha is stored as a float between 0.0-1.0 inclusive. Also
 // see [Hsla].
 type HSLA struct {
-	H uint16
+	H float32
 	S float32
 	L float32
 	A float32
@@ -193,11 +195,11 @@ type HSLA struct {
 func (c HSLA) String() string {
 	s := c.S * 100.0
 	l := c.L * 100.0
-	return fmt.Sprintf("hsla(%d, %.1f%%, %.1f%%, %.2f)", c.H, s, l, c.A)
+	return fmt.Sprintf("hsla(%s, %.1f%%, %.1f%%, %.2f)", formatHue(c.H), s, l, c.A)
 }
 
 // Create an [HSL] color.
-func Hsla(h uint16, s float32, l float32, a float32) HSLA {
+func Hsla(h float32, s float32, l float32, a float32) HSLA {
 	return HSLA{h, s, l, a}
 }
 
@@ -231,13 +233,9 @@ func hueToRgb(v1 float32, v2 float32, vH float32) float32 {
 	return v1
 }
 
-// Convert an [HSLA] into an [RGBA].
-func (c HSLA) ToRgba() RGBA {
-	a := uint8(c.A * 255)
-
+func (c HSLA) toSrgb() (float64, float64, float64, float64) {
 	if c.S <= 0 {
-		value := uint8(c.L * 255)
-		return RGBA{value, value, value, a}
+		return float64(c.L), float64(c.L), float64(c.L), float64(c.A)
 	}
 
 	hue := float32(c.H) / 360
@@ -251,12 +249,22 @@ func (c HSLA) ToRgba() RGBA {
 
 	v1 := 2*c.L - v2
 
-	return RGBA{
-		uint8(255 * hueToRgb(v1, v2, hue+oneThird)),
-		uint8(255 * hueToRgb(v1, v2, hue)),
-		uint8(255 * hueToRgb(v1, v2, hue-oneThird)),
-		a,
-	}
+	return float64(hueToRgb(v1, v2, hue+oneThird)),
+		float64(hueToRgb(v1, v2, hue)),
+		float64(hueToRgb(v1, v2, hue-oneThird)),
+		float64(c.A)
+}
+
+func (c HSL) toSrgb() (float64, float64, float64, float64) {
+	return c.ToHsla().toSrgb()
+}
+
+// Convert an [HSLA] into an [RGBA]. Each channel is rounded to 8 bits, so
+// converting an [RGBA] to [HSLA] and back always gives the original color,
+// but converting to [RGBA] between manipulations such as [RotateHue] may
+// change channels by 1. Chain manipulations on the [HSLA] results instead.
+func (c HSLA) ToRgba() RGBA {
+	return srgbToRgba(c.toSrgb())
 }
 
 // Darken a [Color] by the given amount, which should be a float32 between 0.0
@@ -276,3 +284,105 @@ func Lighten(c Color, amount float32) HSLA {
 	hsla.L = clamp(hsla.L+hsla.L*amount, 0.0, 1.0)
 	return hsla
 }
+
+// Saturate a [Color] by the given amount, which should be a float32 between
+// 0.0 and 1.0, inclusive. As with [Lighten], the amount is relative to the
+// current saturation. Passing a value between 0.0 and -1.0 is equivalent to
+// calling [Desaturate] with a positive value.
+func Saturate(c Color, amount float32) HSLA {
+	hsla := c.ToHsla()
+	hsla.S = clamp(hsla.S+hsla.S*amount, 0.0, 1.0)
+	return hsla
+}
+
+// Desaturate a [Color] by the given amount, which should be a float32 between
+// 0.0 and 1.0, inclusive. As with [Darken], the amount is relative to the
+// current saturation. Passing a value between 0.0 and -1.0 is equivalent to
+// calling [Saturate] with a positive value.
+func Desaturate(c Color, amount float32) HSLA {
+	hsla := c.ToHsla()
+	hsla.S = clamp(hsla.S-hsla.S*amount, 0.0, 1.0)
+	return hsla
+}
+
+// Rotate the hue of a [Color] around the color wheel by the given number of
+// degrees. Negative values rotate in the opposite direction.
+func RotateHue(c Color, degrees int) HSLA {
+	hsla := c.ToHsla()
+	hue := math.Mod(float64(hsla.H)+float64(degrees), 360)
+	if hue < 0 {
+		hue += 360
+	}
+	hsla.H = float32(hue)
+	return hsla
+}
+
+// Get the complement of a [Color], which is the color on the opposite side of
+// the color wheel. This is equivalent to rotating the hue by 180 degrees.
+func Complement(c Color) HSLA {
+	return RotateHue(c, 180)
+}
+
+// Convert a [Color] to grayscale by removing all saturation.
+func Grayscale(c Color) HSLA {
+	hsla := c.ToHsla()
+	hsla.S = 0
+	return hsla
+}
+
+// Invert the red, green and blue channels of a [Color]. The alpha channel is
+// unchanged.
+func Invert(c Color) RGBA {
+	rgba := c.ToRgba()
+	return RGBA{255 - rgba.R, 255 - rgba.G, 255 - rgba.B, rgba.A}
+}
+
+// Mix two colors together. `weight` is the proportion of `a` to use and should
+// be a float32 between 0.0 and 1.0 inclusive, so a weight of 1.0 gives `a`, a
+// weight of 0.0 gives `b`, and a weight of 0.5 gives an even mix of both.
+// Colors are mixed in the sRGB color space, including the alpha channel.
+func Mix(a Color, b Color, weight float32) RGBA {
+	w := clamp(float64(weight), 0.0, 1.0)
+	ar, ag, ab, aa := colorToSrgb(a)
+	br, bg, bb, ba := colorToSrgb(b)
+	return srgbToRgba(
+		ar*w+br*(1-w),
+		ag*w+bg*(1-w),
+		ab*w+bb*(1-w),
+		aa*w+ba*(1-w),
+	)
+}
+
+// Set the alpha channel of a [Color], which should be a float32 between 0.0
+// and 1.0 inclusive. The color keeps its color space where possible: [RGB]
+// and [RGBA] colors return an [RGBA], [HSL] and [HSLA] colors return an
+// [HSLA], and the other color types in this package keep their own type. Any
+// other [Color] is converted to an [RGBA].
+func WithAlpha(c Color, alpha float32) Color {
+	alpha = clamp(alpha, 0.0, 1.0)
+	switch color := c.(type) {
+	case HSL:
+		return HSLA{color.H, color.S, color.L, alpha}
+	case HSLA:
+		color.A = alpha
+		return color
+	case LAB:
+		color.Alpha = alpha
+		return color
+	case LCH:
+		color.Alpha = alpha
+		return color
+	case OKLAB:
+		color.Alpha = alpha
+		return color
+	case OKLCH:
+		color.Alpha = alpha
+		return color
+	case HWB:
+		color.Alpha = alpha
+		return color
+	}
+	rgba := c.ToRgba()
+	rgba.A = floatToChannel(float64(alpha))
+	return rgba
+}
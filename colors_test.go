@@ -61,8 +61,9 @@ func TestRgbToHsla(t *testing.T) {
 	assertEqual(t, Hsla(120, 1, 0.5, 1), green.ToHsla())
 	blue := Rgb(0, 0, 255)
 	assertEqual(t, Hsla(240, 1, 0.5, 1), blue.ToHsla())
-	pink := Rgb(255, 0, 100)
-	assertEqual(t, Hsla(336, 1, 0.5, 1), pink.ToHsla())
+	pink := Rgb(255, 0, 100).ToHsla()
+	assertNear(t, 336.47, pink.H, 0.01)
+	assertEqual(t, Hsla(pink.H, 1, 0.5, 1), pink)
 	cyan := Rgb(0, 102, 102)
 	assertEqual(t, Hsla(180, 1, 0.2, 1), cyan.ToHsla())
 }
@@ -145,3 +146,132 @@ func TestLighten(t *testing.T) {
 	lightened := Lighten(value, 0.1)
 	assertEqual(t, Hsla(120, 0.5, 0.55, 1.0), lightened)
 }
+
+func TestSaturate(t *testing.T) {
+	value := Hsla(120, 0.5, 0.5, 1.0)
+	assertEqual(t, Hsla(120, 0.55, 0.5, 1.0), Saturate(value, 0.1))
+	assertEqual(t, Hsla(120, 1.0, 0.5, 1.0), Saturate(value, 2))
+}
+
+func TestDesaturate(t *testing.T) {
+	value := Hsla(120, 0.5, 0.5, 1.0)
+	assertEqual(t, Hsla(120, 0.45, 0.5, 1.0), Desaturate(value, 0.1))
+	assertEqual(t, Hsla(120, 0.0, 0.5, 1.0), Desaturate(value, 2))
+}
+
+func TestRotateHue(t *testing.T) {
+	value := Hsla(120, 0.5, 0.5, 1.0)
+	assertEqual(t, Hsla(150, 0.5, 0.5, 1.0), RotateHue(value, 30))
+	assertEqual(t, Hsla(90, 0.5, 0.5, 1.0), RotateHue(value, -30))
+	assertEqual(t, Hsla(0, 0.5, 0.5, 1.0), RotateHue(value, 240))
+	assertEqual(t, Hsla(240, 0.5, 0.5, 1.0), RotateHue(value, -600))
+	assertEqual(t, value, RotateHue(value, 360))
+}
+
+func TestComplement(t *testing.T) {
+	assertEqual(t, Hsla(180, 1, 0.5, 1), Complement(Rgb(255, 0, 0)))
+	assertEqual(t, Rgba(0, 255, 255, 255), Complement(Rgb(255, 0, 0)).ToRgba())
+	assertEqual(t, Hsla(300, 0.5, 0.5, 0.5), Complement(Hsla(120, 0.5, 0.5, 0.5)))
+}
+
+func TestGrayscale(t *testing.T) {
+	assertEqual(t, Hsla(0, 0, 0.5, 1), Grayscale(Rgb(255, 0, 0)))
+	assertEqual(t, Rgba(128, 128, 128, 255), Grayscale(Rgb(255, 0, 0)).ToRgba())
+}
+
+func TestInvert(t *testing.T) {
+	assertEqual(t, Rgba(0, 255, 255, 255), Invert(Rgb(255, 0, 0)))
+	assertEqual(t, Rgba(155, 215, 25, 100), Invert(Rgba(100, 40, 230, 100)))
+	assertEqual(t, Rgba(255, 255, 255, 255), Invert(Hsl(0, 0, 0)))
+}
+
+func TestMix(t *testing.T) {
+	red := Rgb(255, 0, 0)
+	blue := Rgb(0, 0, 255)
+	assertEqual(t, Rgba(255, 0, 0, 255), Mix(red, blue, 1))
+	assertEqual(t, Rgba(0, 0, 255, 255), Mix(red, blue, 0))
+	assertEqual(t, Rgba(128, 0, 128, 255), Mix(red, blue, 0.5))
+	assertEqual(t, Rgba(191, 0, 64, 255), Mix(red, blue, 0.75))
+	assertEqual(t, Rgba(255, 0, 0, 255), Mix(red, blue, 2))
+	transparent := Rgba(0, 0, 0, 0)
+	assertEqual(t, Rgba(128, 0, 0, 128), Mix(red, transparent, 0.5))
+}
+
+func TestWithAlpha(t *testing.T) {
+	assertEqual(t, Color(Rgba(1, 2, 3, 128)), WithAlpha(Rgb(1, 2, 3), 0.5))
+	assertEqual(t, Color(Rgba(1, 2, 3, 0)), WithAlpha(Rgba(1, 2, 3, 4), 0))
+	assertEqual(t, Color(Rgba(1, 2, 3, 255)), WithAlpha(Rgba(1, 2, 3, 4), 2))
+	assertEqual(t, Color(Hsla(1, 0.2, 0.3, 0.5)), WithAlpha(Hsl(1, 0.2, 0.3), 0.5))
+	assertEqual(t, Color(Hsla(1, 0.2, 0.3, 0.5)), WithAlpha(Hsla(1, 0.2, 0.3, 1), 0.5))
+	assertEqual(t, Color(LAB{1, 2, 3, 0.5}), WithAlpha(Lab(1, 2, 3), 0.5))
+	assertEqual(t, Color(LCH{1, 2, 3, 0.5}), WithAlpha(Lch(1, 2, 3), 0.5))
+	assertEqual(t, Color(OKLAB{1, 2, 3, 0.5}), WithAlpha(Oklab(1, 2, 3), 0.5))
+	assertEqual(t, Color(OKLCH{1, 2, 3, 0.5}), WithAlpha(Oklch(1, 2, 3), 0.5))
+	assertEqual(t, Color(HWB{1, 0.2, 0.3, 0.5}), WithAlpha(Hwb(1, 0.2, 0.3), 0.5))
+}
+
+// Call a function for a grid of colors covering the RGB cube.
+func forEachTestColor(f func(color RGBA)) {
+	for r := 0; r < 256; r += 3 {
+		for g := 0; g < 256; g += 3 {
+			for b := 0; b < 256; b += 3 {
+				f(Rgba(uint8(r), uint8(g), uint8(b), uint8(255-r)))
+			}
+		}
+	}
+}
+
+func TestHslaRgbaRoundTripDoesNotDrift(t *testing.T) {
+	forEachTestColor(func(color RGBA) {
+		hsla := color.ToHsla()
+		if hsla.ToRgba() != color {
+			t.Fatalf("%v changed to %v", color, hsla.ToRgba())
+		}
+	})
+	hsla := Hsla(210, 0.65, 0.4, 0.3)
+	assertEqual(t, hsla.ToRgba(), hsla.ToRgba().ToHsla().ToRgba())
+}
+
+func TestManipulationsDoNotDrift(t *testing.T) {
+	forEachTestColor(func(color RGBA) {
+		results := []Color{
+			RotateHue(RotateHue(color, 90), -90),
+			Complement(Complement(color)),
+			Darken(color, 0),
+			Lighten(color, 0),
+			Saturate(color, 0),
+			Desaturate(color, 0),
+			Invert(Invert(color)),
+		}
+		for _, result := range results {
+			if result.ToRgba() != color {
+				t.Fatalf("%v changed to %v", color, result.ToRgba())
+			}
+		}
+	})
+}
+
+func TestManipulationsThroughRgbaDriftByAtMostOne(t *testing.T) {
+	near := func(a uint8, b uint8) bool {
+		return a == b || a == b+1 || a+1 == b
+	}
+	forEachTestColor(func(color RGBA) {
+		result := RotateHue(RotateHue(color, 90).ToRgba(), -90).ToRgba()
+		if !near(color.R, result.R) ||
+			!near(color.G, result.G) ||
+			!near(color.B, result.B) ||
+			color.A != result.A {
+			t.Fatalf("%v changed to %v", color, result)
+		}
+	})
+}
+
+func TestRgbToHslaSaturationWithLightMaxChannel(t *testing.T) {
+	hsla := Rgb(36, 102, 168).ToHsla()
+	assertEqual(t, float32(210), hsla.H)
+	assertNear(t, 0.647, hsla.S, 0.001)
+	assertNear(t, 0.4, hsla.L, 0.001)
+	hsla = Rgb(230, 180, 200).ToHsla()
+	assertNear(t, 0.5, hsla.S, 0.01)
+	assertNear(t, 0.804, hsla.L, 0.001)
+}
@@ -0,0 +1,115 @@
+package smetana
+
+import "fmt"
+
+// The method used to generate the colors in a scale with [ColorScale].
+type ColorScaleMode int
+
+const (
+	// Generate tints and shades by mixing the base color with white and
+	// black in the sRGB color space, as in Sass' `tint` and `shade`.
+	ColorScaleMix ColorScaleMode = iota
+	// Generate tints and shades with evenly spaced perceptual lightness by
+	// interpolating in the [OKLCH] color space. The hue of the base color is
+	// preserved and the chroma is reduced as necessary to stay within the
+	// sRGB gamut.
+	ColorScalePerceptual
+)
+
+// The steps used by [ColorScale]: 50, 100, 200, ..., 900.
+var DefaultColorScaleSteps = []int{50, 100, 200, 300, 400, 500, 600, 700, 800, 900}
+
+// Generate a scale of tints and shades from a base [Color] using the
+// [DefaultColorScaleSteps]. The result is a [Palette] with keys such as
+// "primary-50", "primary-100", ..., "primary-900", which can be merged into an
+// existing palette with [MergeMaps]:
+//
+//	palette := Palette{"bg": Hex("#fff")}
+//	MergeMaps(palette, ColorScale("primary", Hex("#3b82f6"), ColorScaleMix))
+//	smetana.AddPalette("light", palette)
+//
+// See [ColorScaleSteps] for more details.
+func ColorScale(name string, base Color, mode ColorScaleMode) Palette {
+	return ColorScaleSteps(name, base, mode, DefaultColorScaleSteps)
+}
+
+// Generate a scale of tints and shades from a base [Color] with the given
+// steps. Each step should be between 0 and 1000: step 500 is the base color,
+// step 0 is white and step 1000 is black, with the other steps interpolated
+// between them using the given [ColorScaleMode]. The keys of the resulting
+// [Palette] are the name and the step separated by a hyphen (ie;
+// "primary-300"). All colors in the scale are [RGB] values.
+func ColorScaleSteps(
+	name string,
+	base Color,
+	mode ColorScaleMode,
+	steps []int,
+) Palette {
+	palette := Palette{}
+	for _, step := range steps {
+		key := fmt.Sprintf("%s-%d", name, step)
+		palette[key] = colorScaleStep(base, mode, step)
+	}
+	return palette
+}
+
+func colorScaleStep(base Color, mode ColorScaleMode, step int) RGB {
+	var rgba RGBA
+	if mode == ColorScalePerceptual {
+		rgba = perceptualScaleStep(base, step)
+	} else {
+		rgba = mixScaleStep(base, step)
+	}
+	return RGB{rgba.R, rgba.G, rgba.B}
+}
+
+func mixScaleStep(base Color, step int) RGBA {
+	step = clamp(step, 0, 1000)
+	if step < 500 {
+		return Mix(Rgb(255, 255, 255), base, float32(500-step)/500)
+	}
+	return Mix(Rgb(0, 0, 0), base, float32(step-500)/500)
+}
+
+func perceptualScaleStep(base Color, step int) RGBA {
+	step = clamp(step, 0, 1000)
+	oklch := ToOklch(base)
+	oklch.Alpha = 1
+	var t float32
+	if step < 500 {
+		t = float32(500-step) / 500
+		oklch.L += (1 - oklch.L) * t
+	} else {
+		t = float32(step-500) / 500
+		oklch.L -= oklch.L * t
+	}
+	oklch.C *= 1 - t
+	return oklchToGamut(oklch).ToRgba()
+}
+
+// Reduce the chroma of an [OKLCH] color until it fits inside the sRGB gamut,
+// preserving its lightness and hue.
+func oklchToGamut(c OKLCH) OKLCH {
+	inGamut := func(c OKLCH) bool {
+		const epsilon = 1e-4
+		r, g, b, _ := c.toSrgb()
+		return r >= -epsilon && r <= 1+epsilon &&
+			g >= -epsilon && g <= 1+epsilon &&
+			b >= -epsilon && b <= 1+epsilon
+	}
+	if inGamut(c) {
+		return c
+	}
+	low := float32(0)
+	high := c.C
+	for i := 0; i < 20; i++ {
+		c.C = (low + high) / 2
+		if inGamut(c) {
+			low = c.C
+		} else {
+			high = c.C
+		}
+	}
+	c.C = low
+	return c
+}
@@ -0,0 +1,68 @@
+package smetana
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestColorScaleMix(t *testing.T) {
+	palette := ColorScale("primary", Rgb(0, 100, 200), ColorScaleMix)
+	assertEqual(t, len(DefaultColorScaleSteps), len(palette))
+	assertEqual(t, fmt.Stringer(Rgb(229, 239, 249)), palette["primary-50"])
+	assertEqual(t, fmt.Stringer(Rgb(204, 224, 244)), palette["primary-100"])
+	assertEqual(t, fmt.Stringer(Rgb(0, 100, 200)), palette["primary-500"])
+	assertEqual(t, fmt.Stringer(Rgb(0, 80, 160)), palette["primary-600"])
+	assertEqual(t, fmt.Stringer(Rgb(0, 20, 40)), palette["primary-900"])
+}
+
+func TestColorScaleStepsAreClamped(t *testing.T) {
+	palette := ColorScaleSteps("gray", Rgb(50, 50, 50), ColorScaleMix, []int{-10, 0, 1000, 1200})
+	assertEqual(t, fmt.Stringer(Rgb(255, 255, 255)), palette["gray--10"])
+	assertEqual(t, fmt.Stringer(Rgb(255, 255, 255)), palette["gray-0"])
+	assertEqual(t, fmt.Stringer(Rgb(0, 0, 0)), palette["gray-1000"])
+	assertEqual(t, fmt.Stringer(Rgb(0, 0, 0)), palette["gray-1200"])
+}
+
+func TestColorScalePerceptual(t *testing.T) {
+	base := Hex("#3b82f6")
+	palette := ColorScale("blue", base, ColorScalePerceptual)
+	assertEqual(t, len(DefaultColorScaleSteps), len(palette))
+	assertEqual(t, fmt.Stringer(base), palette["blue-500"])
+
+	// Lightness should be evenly spaced in OKLCH
+	baseL := ToOklch(base).L
+	for _, step := range []int{50, 100, 200, 300, 400} {
+		color := palette[fmt.Sprintf("blue-%d", step)].(RGB)
+		expected := baseL + (1-baseL)*float32(500-step)/500
+		assertNear(t, expected, ToOklch(color).L, 0.01)
+	}
+	for _, step := range []int{600, 700, 800, 900} {
+		color := palette[fmt.Sprintf("blue-%d", step)].(RGB)
+		expected := baseL - baseL*float32(step-500)/500
+		assertNear(t, expected, ToOklch(color).L, 0.01)
+	}
+}
+
+func TestColorScalePerceptualPreservesHue(t *testing.T) {
+	base := Hex("#e11d48")
+	palette := ColorScale("rose", base, ColorScalePerceptual)
+	baseH := ToOklch(base).H
+	for _, step := range []int{200, 400, 600, 800} {
+		color := palette[fmt.Sprintf("rose-%d", step)].(RGB)
+		assertNear(t, baseH, ToOklch(color).H, 2)
+	}
+}
+
+func TestOklchToGamut(t *testing.T) {
+	inside := Oklch(0.5, 0.05, 120)
+	assertEqual(t, inside, oklchToGamut(inside))
+	outside := Oklch(0.9, 0.4, 142)
+	result := oklchToGamut(outside)
+	assertEqual(t, outside.L, result.L)
+	assertEqual(t, outside.H, result.H)
+	assertEqual(t, true, result.C < outside.C)
+	r, g, b, _ := result.toSrgb()
+	for _, channel := range []float64{r, g, b} {
+		assertEqual(t, true, channel >= -1e-4 && channel <= 1+1e-4)
+	}
+}
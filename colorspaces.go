@@ -0,0 +1,485 @@
+package smetana
+
+import (
+	"fmt"
+	"math"
+)
+
+// Colors implementing [srgbColor] can provide floating point sRGB values
+// directly, which avoids the loss of precision from rounding through an
+// [RGBA] when converting between color spaces.
+type srgbColor interface {
+	toSrgb() (r float64, g float64, b float64, alpha float64)
+}
+
+// Get the gamma-encoded sRGB channels of any [Color] as floats between 0.0
+// and 1.0. Colors outside the sRGB gamut may have channels outside this range.
+func colorToSrgb(c Color) (float64, float64, float64, float64) {
+	if src, ok := c.(srgbColor); ok {
+		return src.toSrgb()
+	}
+	rgba := c.ToRgba()
+	return float64(rgba.R) / 255,
+		float64(rgba.G) / 255,
+		float64(rgba.B) / 255,
+		float64(rgba.A) / 255
+}
+
+func floatToChannel(value float64) uint8 {
+	return uint8(math.Round(clamp(value, 0.0, 1.0) * 255))
+}
+
+// Convert floating point sRGB channels into an [RGBA]. Colors outside the
+// sRGB gamut are clipped.
+func srgbToRgba(r float64, g float64, b float64, alpha float64) RGBA {
+	return RGBA{
+		floatToChannel(r),
+		floatToChannel(g),
+		floatToChannel(b),
+		floatToChannel(alpha),
+	}
+}
+
+func srgbToLinear(value float64) float64 {
+	abs := math.Abs(value)
+	if abs <= 0.04045 {
+		return value / 12.92
+	}
+	return math.Copysign(math.Pow((abs+0.055)/1.055, 2.4), value)
+}
+
+func linearToSrgb(value float64) float64 {
+	abs := math.Abs(value)
+	if abs <= 0.0031308 {
+		return value * 12.92
+	}
+	return math.Copysign(1.055*math.Pow(abs, 1/2.4)-0.055, value)
+}
+
+type matrix3 [3][3]float64
+
+func (m matrix3) apply(x float64, y float64, z float64) (float64, float64, float64) {
+	return m[0][0]*x + m[0][1]*y + m[0][2]*z,
+		m[1][0]*x + m[1][1]*y + m[1][2]*z,
+		m[2][0]*x + m[2][1]*y + m[2][2]*z
+}
+
+// Conversion matrices from CSS Color Module Level 4.
+var (
+	linearSrgbToXyzD65 = matrix3{
+		{0.41239079926595934, 0.357584339383878, 0.1804807884018343},
+		{0.21263900587151027, 0.715168678767756, 0.07219231536073371},
+		{0.01933081871559182, 0.11919477979462598, 0.9505321522496607},
+	}
+	xyzD65ToLinearSrgb = matrix3{
+		{3.2409699419045226, -1.537383177570094, -0.4986107602930034},
+		{-0.9692436362808796, 1.8759675015077202, 0.04155505740717559},
+		{0.05563007969699366, -0.20397695888897652, 1.0569715142428786},
+	}
+	xyzD65ToD50 = matrix3{
+		{1.0479298208405488, 0.022946793341019088, -0.05019222954313557},
+		{0.029627815688159344, 0.990434484573249, -0.01707382502938514},
+		{-0.009243058152591178, 0.015055144896577895, 0.7518742899580008},
+	}
+	xyzD50ToD65 = matrix3{
+		{0.9554734527042182, -0.023098536874261423, 0.0632593086610217},
+		{-0.028369706963208136, 1.0099954580058226, 0.021041398966943008},
+		{0.012314001688319899, -0.020507696433477912, 1.3303659366080753},
+	}
+	linearSrgbToLms = matrix3{
+		{0.4122214708, 0.5363325363, 0.0514459929},
+		{0.2119034982, 0.6806995451, 0.1073969566},
+		{0.0883024619, 0.2817188376, 0.6299787005},
+	}
+	lmsToOklab = matrix3{
+		{0.2104542553, 0.7936177850, -0.0040720468},
+		{1.9779984951, -2.4285922050, 0.4505937099},
+		{0.0259040371, 0.7827717662, -0.8086757660},
+	}
+	oklabToLms = matrix3{
+		{1.0, 0.3963377774, 0.2158037573},
+		{1.0, -0.1055613458, -0.0638541728},
+		{1.0, -0.0894841775, -1.2914855480},
+	}
+	lmsToLinearSrgb = matrix3{
+		{4.0767416621, -3.3077115913, 0.2309699292},
+		{-1.2684380046, 2.6097574011, -0.3413193965},
+		{-0.0041960863, -0.7034186147, 1.7076147010},
+	}
+)
+
+// The D50 reference white used by CIE Lab in CSS.
+var d50White = [3]float64{0.3457 / 0.3585, 1.0, (1.0 - 0.3457 - 0.3585) / 0.3585}
+
+const labEpsilon = 216.0 / 24389.0
+const labKappa = 24389.0 / 27.0
+
+func srgbToLab(r float64, g float64, b float64) (float64, float64, float64) {
+	x, y, z := linearSrgbToXyzD65.apply(
+		srgbToLinear(r),
+		srgbToLinear(g),
+		srgbToLinear(b),
+	)
+	x, y, z = xyzD65ToD50.apply(x, y, z)
+	f := func(value float64) float64 {
+		if value > labEpsilon {
+			return math.Cbrt(value)
+		}
+		return (labKappa*value + 16) / 116
+	}
+	fx := f(x / d50White[0])
+	fy := f(y / d50White[1])
+	fz := f(z / d50White[2])
+	return 116*fy - 16, 500 * (fx - fy), 200 * (fy - fz)
+}
+
+func labToSrgb(l float64, a float64, b float64) (float64, float64, float64) {
+	fy := (l + 16) / 116
+	fx := a/500 + fy
+	fz := fy - b/200
+	finv := func(value float64) float64 {
+		if cube := value * value * value; cube > labEpsilon {
+			return cube
+		}
+		return (116*value - 16) / labKappa
+	}
+	var y float64
+	if l > labKappa*labEpsilon {
+		y = fy * fy * fy
+	} else {
+		y = l / labKappa
+	}
+	x, y, z := xyzD50ToD65.apply(
+		finv(fx)*d50White[0],
+		y*d50White[1],
+		finv(fz)*d50White[2],
+	)
+	lr, lg, lb := xyzD65ToLinearSrgb.apply(x, y, z)
+	return linearToSrgb(lr), linearToSrgb(lg), linearToSrgb(lb)
+}
+
+func srgbToOklab(r float64, g float64, b float64) (float64, float64, float64) {
+	l, m, s := linearSrgbToLms.apply(
+		srgbToLinear(r),
+		srgbToLinear(g),
+		srgbToLinear(b),
+	)
+	return lmsToOklab.apply(math.Cbrt(l), math.Cbrt(m), math.Cbrt(s))
+}
+
+func oklabToSrgb(l float64, a float64, b float64) (float64, float64, float64) {
+	lc, mc, sc := oklabToLms.apply(l, a, b)
+	lr, lg, lb := lmsToLinearSrgb.apply(lc*lc*lc, mc*mc*mc, sc*sc*sc)
+	return linearToSrgb(lr), linearToSrgb(lg), linearToSrgb(lb)
+}
+
+// Convert rectangular "a" and "b" coordinates to polar chroma and hue (in
+// degrees from 0-360).
+func rectToPolar(a float64, b float64) (float64, float64) {
+	c := math.Hypot(a, b)
+	h := math.Atan2(b, a) * 180 / math.Pi
+	if h < 0 {
+		h += 360
+	}
+	return c, h
+}
+
+// Convert polar chroma and hue (in degrees) to rectangular "a" and "b"
+// coordinates.
+func polarToRect(c float64, h float64) (float64, float64) {
+	rad := h * math.Pi / 180
+	return c * math.Cos(rad), c * math.Sin(rad)
+}
+
+func formatColorAlpha(alpha float32) string {
+	if alpha >= 1 {
+		return ""
+	}
+	return fmt.Sprintf(" / %.2f", alpha)
+}
+
+// Structure representing a CIE Lab [Color] using the D50 white point, as in
+// CSS. L is the perceptual lightness from 0.0-100.0 inclusive. A and B are the
+// unbounded green-red and blue-yellow axes, typically between -125.0 and
+// 125.0. Alpha is a float between 0.0-1.0 inclusive. Also see [Lab] and
+// [ToLab].
+type LAB struct {
+	L     float32
+	A     float32
+	B     float32
+	Alpha float32
+}
+
+// Create an opaque [LAB] color.
+func Lab(l float32, a float32, b float32) LAB {
+	return LAB{l, a, b, 1.0}
+}
+
+// Convert any [Color] into a [LAB].
+func ToLab(c Color) LAB {
+	if lab, ok := c.(LAB); ok {
+		return lab
+	}
+	r, g, b, alpha := colorToSrgb(c)
+	l, a, bb := srgbToLab(r, g, b)
+	return LAB{float32(l), float32(a), float32(bb), float32(alpha)}
+}
+
+// Convert a [LAB] color to a CSS string.
+func (c LAB) String() string {
+	return fmt.Sprintf("lab(%.2f%% %.2f %.2f%s)", c.L, c.A, c.B, formatColorAlpha(c.Alpha))
+}
+
+func (c LAB) toSrgb() (float64, float64, float64, float64) {
+	r, g, b := labToSrgb(float64(c.L), float64(c.A), float64(c.B))
+	return r, g, b, float64(c.Alpha)
+}
+
+// Convert a [LAB] into an [HSLA].
+func (c LAB) ToHsla() HSLA {
+	return c.ToRgba().ToHsla()
+}
+
+// Convert a [LAB] into an [RGBA]. Colors outside the sRGB gamut are clipped.
+func (c LAB) ToRgba() RGBA {
+	return srgbToRgba(c.toSrgb())
+}
+
+// Structure representing a CIE LCH [Color], which is the polar form of [LAB].
+// L is the perceptual lightness from 0.0-100.0 inclusive. C is the chroma,
+// typically between 0.0 and 150.0. H is the hue in degrees from 0.0-360.0.
+// Alpha is a float between 0.0-1.0 inclusive. Also see [Lch] and [ToLch].
+type LCH struct {
+	L     float32
+	C     float32
+	H     float32
+	Alpha float32
+}
+
+// Create an opaque [LCH] color.
+func Lch(l float32, c float32, h float32) LCH {
+	return LCH{l, c, h, 1.0}
+}
+
+// Convert any [Color] into an [LCH].
+func ToLch(c Color) LCH {
+	if lch, ok := c.(LCH); ok {
+		return lch
+	}
+	lab := ToLab(c)
+	chroma, hue := rectToPolar(float64(lab.A), float64(lab.B))
+	return LCH{lab.L, float32(chroma), float32(hue), lab.Alpha}
+}
+
+// Convert an [LCH] color to a CSS string.
+func (c LCH) String() string {
+	return fmt.Sprintf("lch(%.2f%% %.2f %.2f%s)", c.L, c.C, c.H, formatColorAlpha(c.Alpha))
+}
+
+func (c LCH) toLab() LAB {
+	a, b := polarToRect(float64(c.C), float64(c.H))
+	return LAB{c.L, float32(a), float32(b), c.Alpha}
+}
+
+func (c LCH) toSrgb() (float64, float64, float64, float64) {
+	return c.toLab().toSrgb()
+}
+
+// Convert an [LCH] into an [HSLA].
+func (c LCH) ToHsla() HSLA {
+	return c.ToRgba().ToHsla()
+}
+
+// Convert an [LCH] into an [RGBA]. Colors outside the sRGB gamut are clipped.
+func (c LCH) ToRgba() RGBA {
+	return srgbToRgba(c.toSrgb())
+}
+
+// Structure representing an OKLab [Color]. L is the perceptual lightness from
+// 0.0-1.0 inclusive. A and B are the unbounded green-red and blue-yellow axes,
+// typically between -0.4 and 0.4. Alpha is a float between 0.0-1.0 inclusive.
+// Also see [Oklab] and [ToOklab].
+type OKLAB struct {
+	L     float32
+	A     float32
+	B     float32
+	Alpha float32
+}
+
+// Create an opaque [OKLAB] color.
+func Oklab(l float32, a float32, b float32) OKLAB {
+	return OKLAB{l, a, b, 1.0}
+}
+
+// Convert any [Color] into an [OKLAB].
+func ToOklab(c Color) OKLAB {
+	if oklab, ok := c.(OKLAB); ok {
+		return oklab
+	}
+	r, g, b, alpha := colorToSrgb(c)
+	l, a, bb := srgbToOklab(r, g, b)
+	return OKLAB{float32(l), float32(a), float32(bb), float32(alpha)}
+}
+
+// Convert an [OKLAB] color to a CSS string.
+func (c OKLAB) String() string {
+	return fmt.Sprintf("oklab(%.2f%% %.4f %.4f%s)", c.L*100, c.A, c.B, formatColorAlpha(c.Alpha))
+}
+
+func (c OKLAB) toSrgb() (float64, float64, float64, float64) {
+	r, g, b := oklabToSrgb(float64(c.L), float64(c.A), float64(c.B))
+	return r, g, b, float64(c.Alpha)
+}
+
+// Convert an [OKLAB] into an [HSLA].
+func (c OKLAB) ToHsla() HSLA {
+	return c.ToRgba().ToHsla()
+}
+
+// Convert an [OKLAB] into an [RGBA]. Colors outside the sRGB gamut are
+// clipped.
+func (c OKLAB) ToRgba() RGBA {
+	return srgbToRgba(c.toSrgb())
+}
+
+// Structure representing an OKLCH [Color], which is the polar form of
+// [OKLAB]. L is the perceptual lightness from 0.0-1.0 inclusive. C is the
+// chroma, typically between 0.0 and 0.4. H is the hue in degrees from
+// 0.0-360.0. Alpha is a float between 0.0-1.0 inclusive. Also see [Oklch] and
+// [ToOklch].
+type OKLCH struct {
+	L     float32
+	C     float32
+	H     float32
+	Alpha float32
+}
+
+// Create an opaque [OKLCH] color.
+func Oklch(l float32, c float32, h float32) OKLCH {
+	return OKLCH{l, c, h, 1.0}
+}
+
+// Convert any [Color] into an [OKLCH].
+func ToOklch(c Color) OKLCH {
+	if oklch, ok := c.(OKLCH); ok {
+		return oklch
+	}
+	oklab := ToOklab(c)
+	chroma, hue := rectToPolar(float64(oklab.A), float64(oklab.B))
+	return OKLCH{oklab.L, float32(chroma), float32(hue), oklab.Alpha}
+}
+
+// Convert an [OKLCH] color to a CSS string.
+func (c OKLCH) String() string {
+	return fmt.Sprintf("oklch(%.2f%% %.4f %.2f%s)", c.L*100, c.C, c.H, formatColorAlpha(c.Alpha))
+}
+
+func (c OKLCH) toOklab() OKLAB {
+	a, b := polarToRect(float64(c.C), float64(c.H))
+	return OKLAB{c.L, float32(a), float32(b), c.Alpha}
+}
+
+func (c OKLCH) toSrgb() (float64, float64, float64, float64) {
+	return c.toOklab().toSrgb()
+}
+
+// Convert an [OKLCH] into an [HSLA].
+func (c OKLCH) ToHsla() HSLA {
+	return c.ToRgba().ToHsla()
+}
+
+// Convert an [OKLCH] into an [RGBA]. Colors outside the sRGB gamut are
+// clipped.
+func (c OKLCH) ToRgba() RGBA {
+	return srgbToRgba(c.toSrgb())
+}
+
+// Structure representing an HWB [Color]. "H" is a float between 0.0-360.0
+// representing a position on the color wheel in degrees, as in [HSL]. W
+// is the amount of white to mix in and B is the amount of black to mix in,
+// both as floats between 0.0-1.0 inclusive. Alpha is a float between 0.0-1.0
+// inclusive. Also see [Hwb] and [ToHwb].
+type HWB struct {
+	H     float32
+	W     float32
+	B     float32
+	Alpha float32
+}
+
+// Create an opaque [HWB] color.
+func Hwb(h float32, w float32, b float32) HWB {
+	return HWB{h, w, b, 1.0}
+}
+
+// Convert any [Color] into an [HWB].
+func ToHwb(c Color) HWB {
+	if hwb, ok := c.(HWB); ok {
+		return hwb
+	}
+	r, g, b, alpha := colorToSrgb(c)
+	value := max(max(r, g), b)
+	white := min(min(r, g), b)
+	var hue float64
+	if delta := value - white; delta > 0 {
+		if value == r {
+			hue = math.Mod((g-b)/delta+6, 6)
+		} else if value == g {
+			hue = (b-r)/delta + 2
+		} else {
+			hue = (r-g)/delta + 4
+		}
+	}
+	return HWB{
+		float32(hue * 60),
+		float32(white),
+		float32(1 - value),
+		float32(alpha),
+	}
+}
+
+// Convert an [HWB] color to a CSS string.
+func (c HWB) String() string {
+	w := c.W * 100.0
+	b := c.B * 100.0
+	return fmt.Sprintf("hwb(%s %.1f%% %.1f%%%s)", formatHue(c.H), w, b, formatColorAlpha(c.Alpha))
+}
+
+func (c HWB) toSrgb() (float64, float64, float64, float64) {
+	w := float64(c.W)
+	b := float64(c.B)
+	if w+b >= 1 {
+		gray := w / (w + b)
+		return gray, gray, gray, float64(c.Alpha)
+	}
+	hue := float64(c.H) / 360
+	channel := func(offset float64) float64 {
+		pure := float64(hueToRgb(0, 1, float32(hue+offset)))
+		return pure*(1-w-b) + w
+	}
+	return channel(oneThird), channel(0), channel(-oneThird), float64(c.Alpha)
+}
+
+// Convert an [HWB] into an [HSLA].
+func (c HWB) ToHsla() HSLA {
+	return c.ToRgba().ToHsla()
+}
+
+// Convert an [HWB] into an [RGBA].
+func (c HWB) ToRgba() RGBA {
+	return srgbToRgba(c.toSrgb())
+}
+
+// Create a pair of [CssProp]s setting `key` to the given [Color]. The first
+// property is an sRGB fallback for older browsers, and the second uses the
+// color's native CSS syntax (such as `oklch()`), which takes precedence in
+// browsers that support it.
+func ColorProps(key string, c Color) CssProps {
+	var fallback Color = c.ToRgba()
+	if rgba := fallback.(RGBA); rgba.A == 255 {
+		fallback = Rgb(rgba.R, rgba.G, rgba.B)
+	}
+	return CssProps{
+		{key, fallback},
+		{key, c},
+	}
+}
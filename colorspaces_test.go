@@ -0,0 +1,203 @@
+package smetana
+
+import (
+	"math"
+	"testing"
+)
+
+func assertNear(t *testing.T, exp float32, got float32, tolerance float32) {
+	if math.Abs(float64(exp-got)) > float64(tolerance) {
+		t.Helper()
+		t.Fatalf("Expecting '%v' got '%v'\n", exp, got)
+	}
+}
+
+func TestSrgbTransferFunctionsRoundTrip(t *testing.T) {
+	for _, value := range []float64{0, 0.01, 0.04045, 0.2, 0.5, 1} {
+		result := linearToSrgb(srgbToLinear(value))
+		assertNear(t, float32(value), float32(result), 1e-6)
+	}
+}
+
+func TestColorToSrgbFallsBackToRgba(t *testing.T) {
+	r, g, b, a := colorToSrgb(Rgba(255, 0, 51, 255))
+	assertEqual(t, []float64{1, 0, 0.2, 1}, []float64{r, g, b, a})
+}
+
+func TestRgbToLab(t *testing.T) {
+	white := ToLab(Rgb(255, 255, 255))
+	assertNear(t, 100, white.L, 0.01)
+	assertNear(t, 0, white.A, 0.01)
+	assertNear(t, 0, white.B, 0.01)
+	red := ToLab(Rgb(255, 0, 0))
+	assertNear(t, 54.29, red.L, 0.01)
+	assertNear(t, 80.80, red.A, 0.01)
+	assertNear(t, 69.89, red.B, 0.01)
+	assertEqual(t, float32(1), red.Alpha)
+}
+
+func TestLabToRgba(t *testing.T) {
+	assertEqual(t, Rgba(255, 0, 0, 255), Lab(54.29, 80.80, 69.89).ToRgba())
+	assertEqual(t, Rgba(0, 0, 0, 255), Lab(0, 0, 0).ToRgba())
+	assertEqual(t, Rgba(255, 255, 255, 255), Lab(100, 0, 0).ToRgba())
+	assertEqual(t, Hsla(0, 1, 0.5, 1), Lab(54.29, 80.80, 69.89).ToHsla())
+}
+
+func TestLabToString(t *testing.T) {
+	assertEqual(t, "lab(54.29% 80.80 69.89)", Lab(54.29, 80.80, 69.89).String())
+	lab := LAB{50, -20, 30, 0.5}
+	assertEqual(t, "lab(50.00% -20.00 30.00 / 0.50)", lab.String())
+}
+
+func TestRgbToLch(t *testing.T) {
+	red := ToLch(Rgb(255, 0, 0))
+	assertNear(t, 54.29, red.L, 0.01)
+	assertNear(t, 106.84, red.C, 0.01)
+	assertNear(t, 40.85, red.H, 0.01)
+	blue := ToLch(Rgb(0, 0, 255))
+	assertNear(t, 301.36, blue.H, 0.01)
+}
+
+func TestLchToRgba(t *testing.T) {
+	assertEqual(t, Rgba(255, 0, 0, 255), Lch(54.29, 106.84, 40.85).ToRgba())
+	assertEqual(t, Hsla(0, 1, 0.5, 1), Lch(54.29, 106.84, 40.85).ToHsla())
+}
+
+func TestLchToString(t *testing.T) {
+	assertEqual(t, "lch(54.29% 106.84 40.85)", Lch(54.29, 106.84, 40.85).String())
+}
+
+func TestRgbToOklab(t *testing.T) {
+	red := ToOklab(Rgb(255, 0, 0))
+	assertNear(t, 0.6280, red.L, 0.0001)
+	assertNear(t, 0.2249, red.A, 0.0001)
+	assertNear(t, 0.1258, red.B, 0.0001)
+	white := ToOklab(Rgb(255, 255, 255))
+	assertNear(t, 1, white.L, 0.0001)
+	assertNear(t, 0, white.A, 0.0001)
+	assertNear(t, 0, white.B, 0.0001)
+}
+
+func TestOklabToRgba(t *testing.T) {
+	assertEqual(t, Rgba(255, 0, 0, 255), Oklab(0.6280, 0.2249, 0.1258).ToRgba())
+	assertEqual(t, Hsla(0, 1, 0.5, 1), Oklab(0.6280, 0.2249, 0.1258).ToHsla())
+}
+
+func TestOklabToString(t *testing.T) {
+	assertEqual(t, "oklab(62.80% 0.2249 0.1258)", Oklab(0.6280, 0.2249, 0.1258).String())
+}
+
+func TestRgbToOklch(t *testing.T) {
+	red := ToOklch(Rgb(255, 0, 0))
+	assertNear(t, 0.6280, red.L, 0.0001)
+	assertNear(t, 0.2577, red.C, 0.0001)
+	assertNear(t, 29.23, red.H, 0.01)
+}
+
+func TestOklchToRgba(t *testing.T) {
+	assertEqual(t, Rgba(255, 0, 0, 255), Oklch(0.6280, 0.2577, 29.23).ToRgba())
+	assertEqual(t, Hsla(0, 1, 0.5, 1), Oklch(0.6280, 0.2577, 29.23).ToHsla())
+	// Out of gamut colors are clipped
+	assertEqual(t, Rgba(0, 255, 0, 255), Oklch(0.9, 0.4, 142).ToRgba())
+}
+
+func TestOklchToString(t *testing.T) {
+	assertEqual(t, "oklch(62.80% 0.2577 29.23)", Oklch(0.6280, 0.2577, 29.23).String())
+	oklch := OKLCH{0.5, 0.1, 200, 0.25}
+	assertEqual(t, "oklch(50.00% 0.1000 200.00 / 0.25)", oklch.String())
+}
+
+func TestRgbToHwb(t *testing.T) {
+	assertEqual(t, Hwb(0, 0, 0), ToHwb(Rgb(255, 0, 0)))
+	assertEqual(t, Hwb(120, 0, 0), ToHwb(Rgb(0, 255, 0)))
+	assertEqual(t, Hwb(240, 0, 0), ToHwb(Rgb(0, 0, 255)))
+	assertEqual(t, Hwb(0, 1, 0), ToHwb(Rgb(255, 255, 255)))
+	assertEqual(t, Hwb(0, 0, 1), ToHwb(Rgb(0, 0, 0)))
+	pink := ToHwb(Rgb(255, 0, 100))
+	assertNear(t, 336.47, pink.H, 0.01)
+	assertEqual(t, HWB{pink.H, 0, 0, 1}, pink)
+	assertEqual(t, Hwb(180, 0, 0.6), ToHwb(Rgb(0, 102, 102)))
+}
+
+func TestHwbToRgba(t *testing.T) {
+	assertEqual(t, Rgba(255, 0, 0, 255), Hwb(0, 0, 0).ToRgba())
+	assertEqual(t, Rgba(0, 102, 102, 255), Hwb(180, 0, 0.6).ToRgba())
+	assertEqual(t, Rgba(255, 128, 128, 255), Hwb(0, 0.5, 0).ToRgba())
+	assertEqual(t, Rgba(128, 128, 128, 255), Hwb(90, 0.6, 0.6).ToRgba())
+	assertEqual(t, Hsla(180, 1, 0.2, 1), Hwb(180, 0, 0.6).ToHsla())
+}
+
+func TestHwbToString(t *testing.T) {
+	assertEqual(t, "hwb(180 0.0% 60.0%)", Hwb(180, 0, 0.6).String())
+	hwb := HWB{90, 0.2, 0.3, 0.5}
+	assertEqual(t, "hwb(90 20.0% 30.0% / 0.50)", hwb.String())
+	assertEqual(t, "hwb(336.47 0.0% 0.0%)", Hwb(336.4706, 0, 0).String())
+}
+
+func TestHwbRgbaRoundTripDoesNotDrift(t *testing.T) {
+	forEachTestColor(func(rgba RGBA) {
+		assertEqual(t, rgba, ToHwb(rgba).ToRgba())
+	})
+}
+
+func TestConvertingToTheSameSpaceIsLossless(t *testing.T) {
+	lab := LAB{12.3456, 7.891, -2.345, 0.5}
+	assertEqual(t, lab, ToLab(lab))
+	lch := LCH{12.3456, 7.891, 2.345, 0.5}
+	assertEqual(t, lch, ToLch(lch))
+	oklab := OKLAB{0.123456, 0.07891, -0.02345, 0.5}
+	assertEqual(t, oklab, ToOklab(oklab))
+	oklch := OKLCH{0.123456, 0.07891, 2.345, 0.5}
+	assertEqual(t, oklch, ToOklch(oklch))
+	hwb := HWB{123, 0.1, 0.2, 0.5}
+	assertEqual(t, hwb, ToHwb(hwb))
+}
+
+func TestConvertBetweenColorSpacesWithoutRgbaRounding(t *testing.T) {
+	oklch := Oklch(0.7, 0.1, 200)
+	result := ToOklch(ToLab(oklch))
+	assertNear(t, oklch.L, result.L, 0.0001)
+	assertNear(t, oklch.C, result.C, 0.0001)
+	assertNear(t, oklch.H, result.H, 0.01)
+}
+
+func TestConvertAlphaBetweenColorSpaces(t *testing.T) {
+	oklch := ToOklch(Hsla(0, 1, 0.5, 0.5))
+	assertEqual(t, float32(0.5), oklch.Alpha)
+	assertEqual(t, uint8(128), oklch.ToRgba().A)
+}
+
+func TestColorProps(t *testing.T) {
+	props := ColorProps("color", Oklch(0.6280, 0.2577, 29.23))
+	assertEqual(t, CssProps{
+		{"color", Rgb(255, 0, 0)},
+		{"color", Oklch(0.6280, 0.2577, 29.23)},
+	}, props)
+	alpha := OKLCH{0.6280, 0.2577, 29.23, 0.5}
+	props = ColorProps("color", alpha)
+	assertEqual(t, CssProps{
+		{"color", Rgba(255, 0, 0, 128)},
+		{"color", alpha},
+	}, props)
+}
+
+func TestHslToSrgb(t *testing.T) {
+	r, g, b, a := colorToSrgb(Hsl(120, 0, 0.25))
+	assertEqual(t, []float64{0.25, 0.25, 0.25, 1}, []float64{r, g, b, a})
+	r, g, b, a = colorToSrgb(Hsl(0, 1, 0.75))
+	assertNear(t, 1, float32(r), 1e-6)
+	assertNear(t, 0.5, float32(g), 1e-6)
+	assertNear(t, 0.5, float32(b), 1e-6)
+	assertEqual(t, 1.0, a)
+}
+
+func TestVeryDarkColorsToLab(t *testing.T) {
+	lab := ToLab(Rgb(1, 1, 1))
+	assertNear(t, 0.27, lab.L, 0.01)
+	assertEqual(t, Rgba(1, 1, 1, 255), lab.ToRgba())
+}
+
+func TestHslaToSrgb(t *testing.T) {
+	r, g, b, a := colorToSrgb(Hsla(240, 1, 0.25, 0.5))
+	assertEqual(t, []float64{0, 0, 0.5, 0.5}, []float64{r, g, b, a})
+}
@@ -0,0 +1,111 @@
+package smetana
+
+// Named content passed to a [Component], created with [Slot].
+type SlotContent struct {
+	Name     string
+	Children Children
+}
+
+// Create named content for a [Component]. Arguments can be any of the child
+// types supported by [NewDomNode] ([Node], []Node, [Children] or `string`).
+// For instance,
+//
+//	Card(props, Slot("footer", Button("Save")), "Body text")
+//
+// passes a button in the "footer" slot and some text as the normal children.
+// Passing multiple slots with the same name appends to the same slot.
+func Slot(name string, args ...any) SlotContent {
+	node := NewDomNode("", args)
+	return SlotContent{name, node.Children}
+}
+
+// The arguments passed to a [Component], other than its props, parsed with
+// the same semantics as [NewDomNode].
+//   - `Attrs` contains all attributes and classes. These are forwarded to
+//     the root node of the component automatically.
+//   - `Children` contains all children that aren't in a slot.
+//   - `Slots` contains the children of each named [Slot].
+type ComponentArgs struct {
+	Attrs    Attrs
+	Children Children
+	Slots    map[string]Children
+	errors   []error
+}
+
+// Get the content of a named slot as a [FragmentNode], which is empty if the
+// slot wasn't passed.
+func (args ComponentArgs) Slot(name string) FragmentNode {
+	return FragmentNode{args.Slots[name]}
+}
+
+// Check whether a named slot was passed to a [Component].
+func (args ComponentArgs) HasSlot(name string) bool {
+	return len(args.Slots[name]) > 0
+}
+
+// Parse the arguments for a [Component].
+func newComponentArgs(args []any) ComponentArgs {
+	slots := map[string]Children{}
+	rest := []any{}
+	for _, arg := range args {
+		if slot, ok := arg.(SlotContent); ok {
+			slots[slot.Name] = append(slots[slot.Name], slot.Children...)
+		} else {
+			rest = append(rest, arg)
+		}
+	}
+	node := NewDomNode("", rest)
+	return ComponentArgs{node.Attrs, node.Children, slots, node.errors}
+}
+
+// A reusable component with typed props of type `P`. Components are created
+// with [NewComponent] and are called like the other DOM node constructors,
+// with the props followed by any arguments supported by [NewDomNode] along
+// with [Slot]s:
+//
+//	type CardProps struct {
+//		Title string
+//	}
+//
+//	var Card = NewComponent(func(props CardProps, args ComponentArgs) DomNode {
+//		return Div(
+//			ClassName("card"),
+//			H2(props.Title),
+//			Div(ClassName("card-body"), args.Children),
+//			args.Slot("footer"),
+//		)
+//	})
+//
+//	Card(
+//		CardProps{"Hello"},
+//		ClassName("wide"),
+//		Attrs{"id": "greeting"},
+//		"Body text",
+//		Slot("footer", Button("OK")),
+//	)
+//
+// Attributes passed to the component are forwarded to the root node that it
+// returns, overriding any attributes with the same name, except for classes
+// which are combined (so the example above renders with `class="card wide"`).
+type Component[P any] func(props P, args ...any) DomNode
+
+// Create a [Component] from a render function. See [Component] for details.
+func NewComponent[P any](
+	render func(props P, args ComponentArgs) DomNode,
+) Component[P] {
+	return func(props P, args ...any) DomNode {
+		componentArgs := newComponentArgs(args)
+		root := render(props, componentArgs)
+		attrs := Attrs{}
+		MergeMaps(attrs, root.Attrs)
+		MergeMaps(attrs, componentArgs.Attrs)
+		if class, ok := componentArgs.Attrs["class"]; ok {
+			attrs["class"] = string(ClassNames(root.Attrs["class"], class))
+		}
+		root.Attrs = attrs
+		for _, err := range componentArgs.errors {
+			root.appendError(err)
+		}
+		return root
+	}
+}
@@ -0,0 +1,95 @@
+package smetana
+
+import (
+	"log"
+	"strings"
+	"testing"
+)
+
+type testCardProps struct {
+	Title string
+}
+
+var testCard = NewComponent(func(props testCardProps, args ComponentArgs) DomNode {
+	footer := Node(Fragment())
+	if args.HasSlot("footer") {
+		footer = Footer(args.Slot("footer"))
+	}
+	return Div(
+		ClassName("card"),
+		H2(props.Title),
+		Div(ClassName("card-body"), args.Children),
+		footer,
+	)
+})
+
+func TestComponentRendersProps(t *testing.T) {
+	node := testCard(testCardProps{"Hello"})
+	assertEqual(
+		t,
+		"<div class=\"card\"><h2>Hello</h2><div class=\"card-body\"></div></div>",
+		RenderHtml(node),
+	)
+}
+
+func TestComponentForwardsChildrenAndAttributes(t *testing.T) {
+	node := testCard(
+		testCardProps{"Hello"},
+		ClassName("wide"),
+		Classes{"hidden": false, "shadow": true},
+		Attrs{"id": "greeting"},
+		Attr{"data-x", "1"},
+		"Body ",
+		Span("text"),
+	)
+	assertEqual(t, "card wide shadow", node.Attrs["class"])
+	assertEqual(t, "greeting", node.Attrs["id"])
+	assertEqual(t, "1", node.Attrs["data-x"])
+	assertEqual(
+		t,
+		"<div class=\"card wide shadow\" data-x=\"1\" id=\"greeting\"><h2>Hello</h2>"+
+			"<div class=\"card-body\">Body <span>text</span></div></div>",
+		RenderHtmlOpts(node, true, nil),
+	)
+}
+
+func TestComponentSlots(t *testing.T) {
+	node := testCard(
+		testCardProps{"Hello"},
+		Slot("footer", Button("OK")),
+		"Body",
+		Slot("footer", "!", Children{Span("a")}),
+	)
+	assertEqual(
+		t,
+		"<div class=\"card\"><h2>Hello</h2><div class=\"card-body\">Body</div>"+
+			"<footer><button>OK</button>!<span>a</span></footer></div>",
+		RenderHtml(node),
+	)
+}
+
+func TestComponentArgsSlotIsEmptyWhenMissing(t *testing.T) {
+	args := newComponentArgs([]any{Slot("a", "x")})
+	assertEqual(t, true, args.HasSlot("a"))
+	assertEqual(t, false, args.HasSlot("b"))
+	assertEqual(t, "", RenderHtml(args.Slot("b")))
+	assertEqual(t, "x", RenderHtml(args.Slot("a")))
+}
+
+func TestComponentDoesNotModifySharedRoot(t *testing.T) {
+	shared := Div(Attrs{"class": "base", "id": "shared"})
+	component := NewComponent(func(props struct{}, args ComponentArgs) DomNode {
+		return shared
+	})
+	node := component(struct{}{}, ClassName("extra"), Attrs{"id": "other"})
+	assertEqual(t, Attrs{"class": "base extra", "id": "other"}, node.Attrs)
+	assertEqual(t, Attrs{"class": "base", "id": "shared"}, shared.Attrs)
+}
+
+func TestComponentReportsInvalidArguments(t *testing.T) {
+	var target strings.Builder
+	logger := log.New(&target, "", 0)
+	node := testCard(testCardProps{"Hello"}, 3.14)
+	RenderHtmlOpts(node, false, logger)
+	assertEqual(t, "Invalid DomNode argument: 3.14\n", target.String())
+}
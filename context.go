@@ -0,0 +1,91 @@
+package smetana
+
+import "context"
+
+// A typed key for storing request-scoped values in the [context.Context] of
+// a [Builder], such as the current user, locale, CSRF token or CSP nonce.
+// Create a key with [NewContextKey], provide a value for a subtree with
+// [ContextKey.Provide], and read it from custom nodes with [ContextKey.Get]:
+//
+//	var CurrentUser = NewContextKey[User]("user")
+//
+//	type UserBadge struct{}
+//
+//	func (node UserBadge) ToHtml(b *Builder) {
+//		if user, ok := CurrentUser.Get(b); ok {
+//			Span(user.Name).ToHtml(b)
+//		}
+//	}
+//
+//	page := CurrentUser.Provide(user, Header(UserBadge{}))
+//
+// Each key created with [NewContextKey] is distinct, even if the names are
+// the same. The name is only used for debugging.
+//
+// Values are hidden inside a [CachedNode], as its HTML is shared between
+// requests, so nodes that read a [ContextKey] shouldn't be cached unless the
+// value is provided inside the cached subtree.
+type ContextKey[T any] struct {
+	Name string
+}
+
+// Create a new [ContextKey] for values of type `T`.
+func NewContextKey[T any](name string) *ContextKey[T] {
+	return &ContextKey[T]{name}
+}
+
+// Get the value for a [ContextKey] from the context of a [Builder], and
+// whether it was found.
+func (key *ContextKey[T]) Get(b *Builder) (T, bool) {
+	return key.FromContext(b.Context())
+}
+
+// Get the value for a [ContextKey] from a [context.Context], and whether it
+// was found. This is useful inside the functions of [AsyncNode]s.
+func (key *ContextKey[T]) FromContext(ctx context.Context) (T, bool) {
+	value, ok := ctx.Value(key).(T)
+	return value, ok
+}
+
+// Get the value for a [ContextKey] from the context of a [Builder], or the
+// given default value if it isn't found.
+func (key *ContextKey[T]) GetOr(b *Builder, fallback T) T {
+	if value, ok := key.Get(b); ok {
+		return value
+	}
+	return fallback
+}
+
+// Create a [ProviderNode] that sets the value for a [ContextKey] while
+// rendering its children.
+func (key *ContextKey[T]) Provide(value T, children ...Node) ProviderNode {
+	return ProviderNode{key, value, children}
+}
+
+// Add the value for a [ContextKey] to a [context.Context]. This can be used
+// to provide values for a whole page before calling a render function such
+// as [RenderHtmlContext].
+func (key *ContextKey[T]) WithValue(
+	ctx context.Context,
+	value T,
+) context.Context {
+	return context.WithValue(ctx, key, value)
+}
+
+// A [Node] that adds a value to the [context.Context] of the [Builder] while
+// its children are rendered, so that the value is scoped to the subtree.
+// This is usually created with [ContextKey.Provide], but any comparable key
+// can be used in the same way as with [context.WithValue].
+type ProviderNode struct {
+	Key      any
+	Value    any
+	Children Children
+}
+
+// Convert a [ProviderNode] to HTML.
+func (node ProviderNode) ToHtml(b *Builder) {
+	parent := b.ctx
+	b.ctx = context.WithValue(b.Context(), node.Key, node.Value)
+	b.writeChildren(node.Children)
+	b.ctx = parent
+}
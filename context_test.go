@@ -0,0 +1,65 @@
+package smetana
+
+import (
+	"context"
+	"testing"
+)
+
+var testLocale = NewContextKey[string]("locale")
+
+type localeNode struct{}
+
+func (node localeNode) ToHtml(b *Builder) {
+	b.Buf.WriteString(testLocale.GetOr(b, "none"))
+}
+
+func TestBuilderHasBackgroundContextByDefault(t *testing.T) {
+	builder := Builder{}
+	assertEqual(t, context.Background(), builder.Context())
+}
+
+func TestProviderScopesValuesToSubtree(t *testing.T) {
+	node := Div(
+		localeNode{},
+		testLocale.Provide("en", Span(localeNode{}), testLocale.Provide(
+			"fr",
+			Span(localeNode{}),
+		)),
+		localeNode{},
+	)
+	assertEqual(
+		t,
+		"<div>none<span>en</span><span>fr</span>none</div>",
+		RenderHtml(node),
+	)
+}
+
+func TestContextKeysAreDistinct(t *testing.T) {
+	other := NewContextKey[string]("locale")
+	node := other.Provide("en", localeNode{})
+	assertEqual(t, "none", RenderHtml(node))
+	builder := Builder{ctx: testLocale.WithValue(context.Background(), "de")}
+	value, ok := testLocale.Get(&builder)
+	assertEqual(t, true, ok)
+	assertEqual(t, "de", value)
+	_, ok = other.Get(&builder)
+	assertEqual(t, false, ok)
+}
+
+func TestRenderContextValuesAreAvailable(t *testing.T) {
+	ctx := testLocale.WithValue(context.Background(), "es")
+	result, err := RenderHtmlContext(ctx, Div(localeNode{}))
+	assertEqual(t, nil, err)
+	assertEqual(t, "<div>es</div>", result)
+}
+
+func TestAsyncNodesReceiveProvidedValues(t *testing.T) {
+	node := testLocale.Provide("it", Async(func(ctx context.Context) (Node, error) {
+		locale, _ := testLocale.FromContext(ctx)
+		return Div(Text(locale), localeNode{}), nil
+	}))
+	result, err := RenderHtmlContext(context.Background(), node)
+	assertEqual(t, nil, err)
+	assertEqual(t, "<div>itit</div>", result)
+	assertEqual(t, "<div>itit</div>", RenderHtml(node))
+}
@@ -0,0 +1,171 @@
+package smetana
+
+import "fmt"
+
+// A WCAG 2.x conformance level for the contrast between two colors. Also see
+// [ContrastRatio].
+type ContrastLevel int
+
+const (
+	// WCAG level AA for normal text, requiring a ratio of at least 4.5:1
+	ContrastAA ContrastLevel = iota
+	// WCAG level AA for large text, requiring a ratio of at least 3:1
+	ContrastAALarge
+	// WCAG level AAA for normal text, requiring a ratio of at least 7:1
+	ContrastAAA
+	// WCAG level AAA for large text, requiring a ratio of at least 4.5:1
+	ContrastAAALarge
+)
+
+// Get the minimum contrast ratio required to meet a [ContrastLevel].
+func (level ContrastLevel) Threshold() float64 {
+	switch level {
+	case ContrastAALarge:
+		return 3
+	case ContrastAAA:
+		return 7
+	}
+	return 4.5
+}
+
+// Convert a [ContrastLevel] to a string.
+func (level ContrastLevel) String() string {
+	switch level {
+	case ContrastAALarge:
+		return "AA (large text)"
+	case ContrastAAA:
+		return "AAA"
+	case ContrastAAALarge:
+		return "AAA (large text)"
+	}
+	return "AA"
+}
+
+// Calculate the WCAG 2.x relative luminance of a [Color], from 0.0 for black
+// to 1.0 for white. The alpha channel is ignored.
+func RelativeLuminance(c Color) float64 {
+	r, g, b, _ := colorToSrgb(c)
+	return luminanceFromSrgb(r, g, b)
+}
+
+func luminanceFromSrgb(r float64, g float64, b float64) float64 {
+	r = srgbToLinear(clamp(r, 0.0, 1.0))
+	g = srgbToLinear(clamp(g, 0.0, 1.0))
+	b = srgbToLinear(clamp(b, 0.0, 1.0))
+	return 0.2126*r + 0.7152*g + 0.0722*b
+}
+
+// Calculate the WCAG 2.x contrast ratio between a foreground and a background
+// [Color], from 1.0 for identical colors to 21.0 for black on white. If the
+// foreground is translucent then it is first blended onto the background,
+// which is assumed to be opaque.
+func ContrastRatio(foreground Color, background Color) float64 {
+	fr, fg, fb, fa := colorToSrgb(foreground)
+	br, bg, bb, _ := colorToSrgb(background)
+	if fa < 1 {
+		fr = fr*fa + br*(1-fa)
+		fg = fg*fa + bg*(1-fa)
+		fb = fb*fa + bb*(1-fa)
+	}
+	l1 := luminanceFromSrgb(fr, fg, fb)
+	l2 := luminanceFromSrgb(br, bg, bb)
+	return (max(l1, l2) + 0.05) / (min(l1, l2) + 0.05)
+}
+
+// A foreground and background color pair from a [Palette] to be checked with
+// [Smetana.CheckContrast].
+type ContrastPair struct {
+	Foreground PaletteValue
+	Background PaletteValue
+}
+
+// A [ContrastPair] that did not meet the required [ContrastLevel] in a
+// particular [Palette]. If either palette value was missing or was not a
+// [Color] then `Ratio` is 0 and `Err` describes the problem.
+type ContrastFailure struct {
+	Palette string
+	Pair    ContrastPair
+	Level   ContrastLevel
+	Ratio   float64
+	Err     error
+}
+
+// Convert a [ContrastFailure] to a human-readable string.
+func (failure ContrastFailure) Error() string {
+	if failure.Err != nil {
+		return fmt.Sprintf(
+			"Palette %s: %s on %s: %s",
+			failure.Palette,
+			failure.Pair.Foreground,
+			failure.Pair.Background,
+			failure.Err,
+		)
+	}
+	return fmt.Sprintf(
+		"Palette %s: %s on %s has contrast ratio %.2f:1, %s requires %.1f:1",
+		failure.Palette,
+		failure.Pair.Foreground,
+		failure.Pair.Background,
+		failure.Ratio,
+		failure.Level,
+		failure.Level.Threshold(),
+	)
+}
+
+func paletteColor(palette Palette, key PaletteValue) (Color, error) {
+	value := palette[string(key)]
+	if value == nil {
+		return nil, fmt.Errorf("Missing palette value: %s", key)
+	}
+	color, ok := unwrapTokenAlias(value).(Color)
+	if !ok {
+		return nil, fmt.Errorf("Palette value is not a color: %s", key)
+	}
+	return color, nil
+}
+
+// Check the contrast of a [ContrastPair] in a single [Palette]. Returns nil
+// if the pair meets the required [ContrastLevel].
+func checkPaletteContrast(
+	name string,
+	palette Palette,
+	pair ContrastPair,
+	level ContrastLevel,
+) *ContrastFailure {
+	failure := ContrastFailure{name, pair, level, 0, nil}
+	foreground, err := paletteColor(palette, pair.Foreground)
+	if err != nil {
+		failure.Err = err
+		return &failure
+	}
+	background, err := paletteColor(palette, pair.Background)
+	if err != nil {
+		failure.Err = err
+		return &failure
+	}
+	failure.Ratio = ContrastRatio(foreground, background)
+	if failure.Ratio < level.Threshold() {
+		return &failure
+	}
+	return nil
+}
+
+// Check that every [ContrastPair] meets the given [ContrastLevel] in every
+// [Palette] in the [Smetana] context. Failures are returned in order of
+// palette name and then in the order of `pairs`. An empty result means all
+// pairs passed.
+func (s Smetana) CheckContrast(
+	pairs []ContrastPair,
+	level ContrastLevel,
+) []ContrastFailure {
+	failures := []ContrastFailure{}
+	for _, name := range s.paletteNames() {
+		for _, pair := range pairs {
+			failure := checkPaletteContrast(name, s.Palettes[name], pair, level)
+			if failure != nil {
+				failures = append(failures, *failure)
+			}
+		}
+	}
+	return failures
+}
@@ -0,0 +1,102 @@
+package smetana
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestContrastLevelThreshold(t *testing.T) {
+	assertEqual(t, 4.5, ContrastAA.Threshold())
+	assertEqual(t, 3.0, ContrastAALarge.Threshold())
+	assertEqual(t, 7.0, ContrastAAA.Threshold())
+	assertEqual(t, 4.5, ContrastAAALarge.Threshold())
+}
+
+func TestContrastLevelToString(t *testing.T) {
+	assertEqual(t, "AA", ContrastAA.String())
+	assertEqual(t, "AA (large text)", ContrastAALarge.String())
+	assertEqual(t, "AAA", ContrastAAA.String())
+	assertEqual(t, "AAA (large text)", ContrastAAALarge.String())
+}
+
+func TestRelativeLuminance(t *testing.T) {
+	assertEqual(t, 0.0, RelativeLuminance(Rgb(0, 0, 0)))
+	assertNear(t, 1.0, float32(RelativeLuminance(Rgb(255, 255, 255))), 1e-6)
+	assertNear(t, 0.2126, float32(RelativeLuminance(Rgb(255, 0, 0))), 1e-6)
+	assertNear(t, 0.2159, float32(RelativeLuminance(Hex("#808080"))), 1e-4)
+}
+
+func TestContrastRatio(t *testing.T) {
+	black := Rgb(0, 0, 0)
+	white := Rgb(255, 255, 255)
+	assertNear(t, 21, float32(ContrastRatio(black, white)), 1e-4)
+	assertNear(t, 21, float32(ContrastRatio(white, black)), 1e-4)
+	assertNear(t, 1, float32(ContrastRatio(white, white)), 1e-4)
+	assertNear(t, 4.48, float32(ContrastRatio(Hex("#777"), white)), 0.01)
+	assertNear(t, 4.54, float32(ContrastRatio(Hex("#767676"), white)), 0.01)
+}
+
+func TestContrastRatioBlendsTranslucentForeground(t *testing.T) {
+	white := Rgb(255, 255, 255)
+	transparent := Rgba(0, 0, 0, 0)
+	assertNear(t, 1, float32(ContrastRatio(transparent, white)), 1e-4)
+	half := Hsla(0, 0, 0, 0.5)
+	expected := ContrastRatio(Hsl(0, 0, 0.5), white)
+	assertNear(t, float32(expected), float32(ContrastRatio(half, white)), 1e-4)
+}
+
+func TestCheckContrastAcrossPalettes(t *testing.T) {
+	smetana := NewSmetanaWithPalettes(Palettes{
+		"light": {
+			"fg":    Hex("#222"),
+			"bg":    Hex("#fff"),
+			"muted": Hex("#999"),
+		},
+		"dark": {
+			"fg":    Hex("#ddd"),
+			"bg":    Hex("#111"),
+			"muted": Hex("#444"),
+		},
+	})
+	pairs := []ContrastPair{
+		{"fg", "bg"},
+		{"muted", "bg"},
+	}
+	failures := smetana.CheckContrast(pairs, ContrastAA)
+	assertEqual(t, 2, len(failures))
+	assertEqual(t, "dark", failures[0].Palette)
+	assertEqual(t, ContrastPair{"muted", "bg"}, failures[0].Pair)
+	assertEqual(t, ContrastAA, failures[0].Level)
+	assertEqual(t, nil, failures[0].Err)
+	assertEqual(t, "light", failures[1].Palette)
+	assertEqual(t, ContrastPair{"muted", "bg"}, failures[1].Pair)
+	assertEqual(
+		t,
+		"Palette light: muted on bg has contrast ratio 2.85:1, AA requires 4.5:1",
+		failures[1].Error(),
+	)
+	assertEqual(t, 0, len(smetana.CheckContrast(pairs[:1], ContrastAAA)))
+}
+
+func TestCheckContrastReportsInvalidPaletteValues(t *testing.T) {
+	smetana := NewSmetanaWithPalettes(Palettes{
+		"default": {
+			"fg":   Hex("#000"),
+			"size": PX(4),
+		},
+	})
+	pairs := []ContrastPair{
+		{"fg", "missing"},
+		{"size", "fg"},
+	}
+	failures := smetana.CheckContrast(pairs, ContrastAA)
+	assertEqual(t, 2, len(failures))
+	assertEqual(t, 0.0, failures[0].Ratio)
+	assertEqual(t, errors.New("Missing palette value: missing"), failures[0].Err)
+	assertEqual(
+		t,
+		"Palette default: fg on missing: Missing palette value: missing",
+		failures[0].Error(),
+	)
+	assertEqual(t, errors.New("Palette value is not a color: size"), failures[1].Err)
+}
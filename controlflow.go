@@ -0,0 +1,123 @@
+package smetana
+
+// A [Node] that renders one of two nodes depending on a condition. Create an
+// [IfNode] with [If] or [IfElse]:
+//
+//	Nav(
+//		If(user.IsAdmin, AHref("/admin", "Admin")),
+//		If(user.LoggedIn, Span(user.Name)).Else(AHref("/login", "Log in")),
+//	)
+//
+// Either node may be nil to render nothing, matching how [NewDomNode]
+// ignores nil arguments. Note that both nodes are constructed before the
+// condition is checked, as with any other function arguments.
+type IfNode struct {
+	Condition bool
+	Then      Node
+	Otherwise Node
+}
+
+// Create an [IfNode] that renders the node if the condition is true, or
+// nothing otherwise.
+func If(condition bool, node Node) IfNode {
+	return IfNode{condition, node, nil}
+}
+
+// Create an [IfNode] that renders the first node if the condition is true,
+// or the second node otherwise.
+func IfElse(condition bool, then Node, otherwise Node) IfNode {
+	return IfNode{condition, then, otherwise}
+}
+
+// Set the node to be rendered if the condition of an [IfNode] is false.
+func (node IfNode) Else(otherwise Node) IfNode {
+	node.Otherwise = otherwise
+	return node
+}
+
+// Convert an [IfNode] to HTML.
+func (node IfNode) ToHtml(b *Builder) {
+	if node.Condition {
+		writeOptionalNode(b, node.Then)
+	} else {
+		writeOptionalNode(b, node.Otherwise)
+	}
+}
+
+// Create a [FragmentNode] by converting each item in a slice into a [Node].
+// This is a shortcut for using [Xform] with [FragmentNode], and any nil
+// nodes that are returned are skipped. For example,
+//
+//	Ul(MapNodes(titles, func(title string) Node {
+//		return Li(title)
+//	}))
+func MapNodes[T any](items []T, xform func(item T) Node) FragmentNode {
+	children := Children{}
+	for _, child := range Xform(items, xform) {
+		if child != nil {
+			children = append(children, child)
+		}
+	}
+	return FragmentNode{children}
+}
+
+// A single case of a [SwitchNode].
+type SwitchCase[T comparable] struct {
+	Value T
+	Node  Node
+}
+
+// A [Node] that renders the node for the first case matching a value, or a
+// default node if no cases match. Create a [SwitchNode] with [Switch] and
+// add cases with [SwitchNode.Case] and [SwitchNode.Default]:
+//
+//	Switch(order.Status).
+//		Case("pending", Span("Awaiting payment")).
+//		Case("shipped", Span("On its way")).
+//		Default(Span("Unknown status"))
+//
+// Nodes may be nil to render nothing.
+type SwitchNode[T comparable] struct {
+	Value    T
+	Cases    []SwitchCase[T]
+	Fallback Node
+}
+
+// Create a [SwitchNode] for the given value.
+func Switch[T comparable](value T) SwitchNode[T] {
+	return SwitchNode[T]{value, nil, nil}
+}
+
+// Add a case to a [SwitchNode]. Cases are checked in the order they are
+// added.
+func (node SwitchNode[T]) Case(value T, child Node) SwitchNode[T] {
+	node.Cases = append(
+		append([]SwitchCase[T]{}, node.Cases...),
+		SwitchCase[T]{value, child},
+	)
+	return node
+}
+
+// Set the node to be rendered if no cases of a [SwitchNode] match.
+func (node SwitchNode[T]) Default(child Node) SwitchNode[T] {
+	node.Fallback = child
+	return node
+}
+
+// Convert a [SwitchNode] to HTML.
+func (node SwitchNode[T]) ToHtml(b *Builder) {
+	for _, c := range node.Cases {
+		if c.Value == node.Value {
+			writeOptionalNode(b, c.Node)
+			return
+		}
+	}
+	writeOptionalNode(b, node.Fallback)
+}
+
+// Write a [Node] to a [Builder] if it isn't nil.
+func writeOptionalNode(b *Builder, node Node) {
+	if node != nil {
+		node.ToHtml(b)
+	}
+}
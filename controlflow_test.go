@@ -0,0 +1,65 @@
+package smetana
+
+import "testing"
+
+func TestIf(t *testing.T) {
+	assertEqual(t, "<div><p>Yes</p></div>", RenderHtml(Div(If(true, P("Yes")))))
+	assertEqual(t, "<div></div>", RenderHtml(Div(If(false, P("Yes")))))
+	assertEqual(t, "<div></div>", RenderHtml(Div(If(true, nil))))
+}
+
+func TestIfElse(t *testing.T) {
+	assertEqual(t, "<p>a</p>", RenderHtml(IfElse(true, P("a"), P("b"))))
+	assertEqual(t, "<p>b</p>", RenderHtml(IfElse(false, P("a"), P("b"))))
+	assertEqual(t, "<p>b</p>", RenderHtml(If(false, P("a")).Else(P("b"))))
+	assertEqual(t, "", RenderHtml(IfElse(false, P("a"), nil)))
+}
+
+func TestControlFlowNodesCanBeChildren(t *testing.T) {
+	node := Div(Children{
+		If(false, P("a")),
+		IfElse(false, nil, P("b")),
+		Switch(1).Case(2, P("c")),
+	})
+	assertEqual(t, "<div><p>b</p></div>", RenderHtml(node))
+}
+
+func TestMapNodes(t *testing.T) {
+	titles := []string{"a", "b", "c"}
+	node := Ul(MapNodes(titles, func(title string) Node {
+		if title == "b" {
+			return nil
+		}
+		return Li(title)
+	}))
+	assertEqual(t, "<ul><li>a</li><li>c</li></ul>", RenderHtml(node))
+	var empty []int
+	assertEqual(t, "<ul></ul>", RenderHtml(Ul(MapNodes(empty, func(i int) Node {
+		return Li("x")
+	}))))
+}
+
+func TestSwitch(t *testing.T) {
+	status := func(value string) Node {
+		return Switch(value).
+			Case("pending", Span("Awaiting payment")).
+			Case("shipped", Span("On its way")).
+			Case("shipped", Span("Duplicate")).
+			Case("cancelled", nil).
+			Default(Span("Unknown"))
+	}
+	assertEqual(t, "<span>Awaiting payment</span>", RenderHtml(status("pending")))
+	assertEqual(t, "<span>On its way</span>", RenderHtml(status("shipped")))
+	assertEqual(t, "", RenderHtml(status("cancelled")))
+	assertEqual(t, "<span>Unknown</span>", RenderHtml(status("other")))
+	assertEqual(t, "", RenderHtml(Switch(3).Case(1, P("one"))))
+}
+
+func TestSwitchCasesDoNotShareState(t *testing.T) {
+	base := Switch(1).Case(1, P("one"))
+	base.Cases = append(make([]SwitchCase[int], 0, 4), base.Cases...)
+	a := base.Case(2, P("a"))
+	b := base.Case(2, P("b"))
+	assertEqual(t, "a", RenderHtml(a.Cases[1].Node.(DomNode).Children[0]))
+	assertEqual(t, "b", RenderHtml(b.Cases[1].Node.(DomNode).Children[0]))
+}
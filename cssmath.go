@@ -0,0 +1,182 @@
+package smetana
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// A binary arithmetic operation inside a CSS math expression. This is usually
+// created with the [CssCalc.Add], [CssCalc.Sub], [CssCalc.Mul] and
+// [CssCalc.Div] methods rather than directly.
+type CssMathOp struct {
+	Op    byte
+	Left  any
+	Right any
+}
+
+// A CSS `calc()` expression implementing [CssValue]. Operands can be any type
+// supported by [CssValueToString], other math expressions such as [CssCalc],
+// [CssMathOp] and [CssMathFunc], or plain numbers (int, float32 or float64)
+// which are rendered without units. Use the [Calc] constructor along with the
+// operator methods to build expressions:
+//
+//	Calc(PX(16)).Add(VW(2)).Mul(1.5)
+//
+// will render to `calc((16px + 2vw) * 1.5)`. Operations are nested in the
+// order the methods are called.
+type CssCalc struct {
+	Expr any
+}
+
+// Create a [CssCalc] expression with an initial value.
+func Calc(value any) CssCalc {
+	return CssCalc{value}
+}
+
+// Add a value to a [CssCalc] expression.
+func (calc CssCalc) Add(value any) CssCalc {
+	return CssCalc{CssMathOp{'+', calc.Expr, value}}
+}
+
+// Subtract a value from a [CssCalc] expression.
+func (calc CssCalc) Sub(value any) CssCalc {
+	return CssCalc{CssMathOp{'-', calc.Expr, value}}
+}
+
+// Multiply a [CssCalc] expression by a value.
+func (calc CssCalc) Mul(value any) CssCalc {
+	return CssCalc{CssMathOp{'*', calc.Expr, value}}
+}
+
+// Divide a [CssCalc] expression by a value.
+func (calc CssCalc) Div(value any) CssCalc {
+	return CssCalc{CssMathOp{'/', calc.Expr, value}}
+}
+
+// Convert a [CssCalc] into a CSS string.
+func (calc CssCalc) ToCssValue(palette Palette) (string, error) {
+	inner, err := mathExprToString(palette, calc.Expr, false)
+	return "calc(" + inner + ")", err
+}
+
+// Get all of the [PaletteValue]s referenced by a [CssCalc].
+func (calc CssCalc) PaletteValues() []PaletteValue {
+	return cssValuePaletteValues(calc.Expr)
+}
+
+// Convert a [CssMathOp] into a CSS string. As a standalone value it is
+// wrapped in `calc()`.
+func (op CssMathOp) ToCssValue(palette Palette) (string, error) {
+	return CssCalc{op}.ToCssValue(palette)
+}
+
+// Get all of the [PaletteValue]s referenced by a [CssMathOp].
+func (op CssMathOp) PaletteValues() []PaletteValue {
+	return append(
+		cssValuePaletteValues(op.Left),
+		cssValuePaletteValues(op.Right)...,
+	)
+}
+
+func (op CssMathOp) toString(palette Palette) (string, error) {
+	left, leftErr := mathExprToString(palette, op.Left, true)
+	right, rightErr := mathExprToString(palette, op.Right, true)
+	err := leftErr
+	if err == nil {
+		err = rightErr
+	}
+	return fmt.Sprintf("%s %c %s", left, op.Op, right), err
+}
+
+// A CSS math function such as `min()`, `max()` or `clamp()` implementing
+// [CssValue]. Arguments can be any type supported by [CssCalc]. Also see
+// [Min], [Max] and [Clamp].
+type CssMathFunc struct {
+	Name string
+	Args []any
+}
+
+// Create a CSS `min()` function with the given arguments.
+func Min(args ...any) CssMathFunc {
+	return CssMathFunc{"min", args}
+}
+
+// Create a CSS `max()` function with the given arguments.
+func Max(args ...any) CssMathFunc {
+	return CssMathFunc{"max", args}
+}
+
+// Create a CSS `clamp()` function that restricts `value` to be between
+// `minimum` and `maximum`. For example, fluid typography can be created with:
+//
+//	Clamp(REM(1), Calc(REM(0.5)).Add(VW(2)), REM(2))
+func Clamp(minimum any, value any, maximum any) CssMathFunc {
+	return CssMathFunc{"clamp", []any{minimum, value, maximum}}
+}
+
+// Add a value to a [CssMathFunc], creating a [CssCalc] expression.
+func (fn CssMathFunc) Add(value any) CssCalc {
+	return Calc(fn).Add(value)
+}
+
+// Subtract a value from a [CssMathFunc], creating a [CssCalc] expression.
+func (fn CssMathFunc) Sub(value any) CssCalc {
+	return Calc(fn).Sub(value)
+}
+
+// Multiply a [CssMathFunc] by a value, creating a [CssCalc] expression.
+func (fn CssMathFunc) Mul(value any) CssCalc {
+	return Calc(fn).Mul(value)
+}
+
+// Divide a [CssMathFunc] by a value, creating a [CssCalc] expression.
+func (fn CssMathFunc) Div(value any) CssCalc {
+	return Calc(fn).Div(value)
+}
+
+// Convert a [CssMathFunc] into a CSS string.
+func (fn CssMathFunc) ToCssValue(palette Palette) (string, error) {
+	var err error
+	args := make([]string, len(fn.Args))
+	for i, arg := range fn.Args {
+		str, argErr := mathExprToString(palette, arg, false)
+		if err == nil {
+			err = argErr
+		}
+		args[i] = str
+	}
+	return fn.Name + "(" + strings.Join(args, ", ") + ")", err
+}
+
+// Get all of the [PaletteValue]s referenced by a [CssMathFunc].
+func (fn CssMathFunc) PaletteValues() []PaletteValue {
+	values := []PaletteValue{}
+	for _, arg := range fn.Args {
+		values = append(values, cssValuePaletteValues(arg)...)
+	}
+	return values
+}
+
+// Convert a value inside a math expression into a string. Nested operations
+// don't need to be wrapped in `calc()`, but are wrapped in parentheses if
+// `isOperand` is true to preserve the order of operations.
+func mathExprToString(palette Palette, value any, isOperand bool) (string, error) {
+	switch item := value.(type) {
+	case CssCalc:
+		return mathExprToString(palette, item.Expr, isOperand)
+	case CssMathOp:
+		str, err := item.toString(palette)
+		if isOperand {
+			str = "(" + str + ")"
+		}
+		return str, err
+	case int:
+		return strconv.Itoa(item), nil
+	case float32:
+		return formatFloatUnits(item, ""), nil
+	case float64:
+		return formatFloatUnits(item, ""), nil
+	}
+	return CssValueToString(palette, value)
+}
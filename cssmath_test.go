@@ -0,0 +1,72 @@
+package smetana
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRenderCalc(t *testing.T) {
+	palette := Palette{"gap": REM(1)}
+	tests := map[string]any{
+		"calc(10px)":                       Calc(PX(10)),
+		"calc(16px + 2vw)":                 Calc(PX(16)).Add(VW(2)),
+		"calc(100% - 1rem)":                Calc(Perc(100)).Sub(PaletteValue("gap")),
+		"calc((16px + 2vw) * 1.50)":        Calc(PX(16)).Add(VW(2)).Mul(1.5),
+		"calc(1px * 0.33)":                 Calc(PX(1)).Mul(1.0 / 3),
+		"calc(100vh / 3)":                  Calc(VH(100)).Div(3),
+		"calc(2 * 1.25)":                   Calc(2).Mul(float32(1.25)),
+		"calc(1rem + (2px * 3))":           Calc(REM(1)).Add(Calc(PX(2)).Mul(3)),
+		"calc(var(--x) - 1px)":             Calc("var(--x)").Sub(PX(1)),
+		"calc(1px + 2px)":                  CssMathOp{'+', PX(1), PX(2)},
+		"calc(min(1px, 2px) + 3px)":        Min(PX(1), PX(2)).Add(PX(3)),
+		"calc(max(1px, 2px) - 3px)":        Max(PX(1), PX(2)).Sub(PX(3)),
+		"calc(min(1px, 2px) * 3)":          Min(PX(1), PX(2)).Mul(3),
+		"calc(max(1px, 2px) / 3)":          Max(PX(1), PX(2)).Div(3),
+		"min(50%, 500px)":                  Min(Perc(50), PX(500)),
+		"max(1rem, 10vw - 2px)":            Max(REM(1), Calc(VW(10)).Sub(PX(2))),
+		"clamp(1rem, 0.50rem + 2vw, 2rem)": Clamp(REM(1), Calc(REM(0.5)).Add(VW(2)), REM(2)),
+		"min(1rem, max(1rem, 2vw))":        Min(PaletteValue("gap"), Max(REM(1), VW(2))),
+	}
+	for expected, value := range tests {
+		result, err := CssValueToString(palette, value)
+		assertEqual(t, nil, err)
+		assertEqual(t, expected, result)
+	}
+}
+
+func TestCalcCanBeUsedInStyleSheets(t *testing.T) {
+	styles := NewStyleSheet(StylesBlock("h1", CssProps{
+		{"font-size", Clamp(REM(1), Calc(REM(0.5)).Add(VW(2)), REM(2))},
+	}))
+	expected := "h1{font-size:clamp(1rem, 0.50rem + 2vw, 2rem);}"
+	assertEqual(t, expected, RenderCss(styles, Palette{}))
+}
+
+func TestCalcReportsMissingPaletteValues(t *testing.T) {
+	expected := errors.New("Missing palette value: a")
+	result, err := CssValueToString(Palette{}, Calc(PaletteValue("a")).Add(PX(1)))
+	assertEqual(t, "calc(inherit + 1px)", result)
+	assertEqual(t, expected, err)
+	result, err = CssValueToString(Palette{}, Calc(PX(1)).Add(PaletteValue("a")))
+	assertEqual(t, "calc(1px + inherit)", result)
+	assertEqual(t, expected, err)
+	result, err = CssValueToString(Palette{}, Min(PX(1), PaletteValue("a")))
+	assertEqual(t, "min(1px, inherit)", result)
+	assertEqual(t, expected, err)
+}
+
+func TestCalcPaletteValues(t *testing.T) {
+	value := Clamp(
+		PaletteValue("a"),
+		Calc(PaletteValue("b")).Mul(2).Add(PX(1)),
+		Max(PaletteValue("c"), PX(2)),
+	)
+	expected := []PaletteValue{"a", "b", "c"}
+	assertEqual(t, expected, value.PaletteValues())
+	smetana := NewSmetanaWithPalettes(Palettes{"default": {"a": PX(1)}})
+	smetana.Styles.AddBlock("body", CssProps{{"padding", value}})
+	assertEqual(t, []MissingPaletteValue{
+		{"default", "b"},
+		{"default", "c"},
+	}, smetana.ValidatePalettes())
+}
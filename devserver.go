@@ -0,0 +1,281 @@
+package smetana
+
+import (
+	"bytes"
+	"context"
+	"io/fs"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// A development server that wraps another [http.Handler] (usually a [Handler]
+// or the result of [Site.Handler]) and reloads the browser when the page
+// changes. A small script is injected into every HTML response which listens
+// for reload events sent with Server-Sent Events from `ReloadPath`.
+//
+// Reload events are sent whenever [DevServer.Reload] is called (ie; after
+// rebuilding a [Smetana] context), or when any of the files in `WatchPaths`
+// change. Files are watched by polling their modification times every
+// `PollInterval`, so no extra dependencies are required. If `OnChange` is
+// set then it's called before reloading when watched files change. For
+// example,
+//
+//	server := NewDevServer(site.Handler())
+//	server.WatchPaths = []string{"templates", "tokens.json"}
+//	server.OnChange = rebuildStyles
+//	server.ListenAndServe(":8080")
+//
+// Responses from the wrapped handler are never cached or compressed so that
+// the script can always be injected. This should not be used in production.
+type DevServer struct {
+	Handler      http.Handler
+	ReloadPath   string
+	WatchPaths   []string
+	PollInterval time.Duration
+	OnChange     func()
+	Logger       *log.Logger
+	mutex        sync.Mutex
+	clients      map[chan struct{}]bool
+}
+
+// Create a new [DevServer] wrapping the given [http.Handler]. Reload events
+// are served from "/__smetana/reload" and watched files are polled every
+// 500 milliseconds.
+func NewDevServer(handler http.Handler) *DevServer {
+	return &DevServer{
+		Handler:      handler,
+		ReloadPath:   "/__smetana/reload",
+		PollInterval: 500 * time.Millisecond,
+		Logger:       log.New(os.Stderr, "", 0),
+	}
+}
+
+// Get the script injected into HTML responses to listen for reload events.
+func (server *DevServer) reloadScript() string {
+	return "<script>new EventSource(\"" + server.ReloadPath +
+		"\").onmessage=function(){location.reload()}</script>"
+}
+
+// Send a reload event to all connected browsers.
+func (server *DevServer) Reload() {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	for client := range server.clients {
+		select {
+		case client <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (server *DevServer) addClient() chan struct{} {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	if server.clients == nil {
+		server.clients = map[chan struct{}]bool{}
+	}
+	client := make(chan struct{}, 1)
+	server.clients[client] = true
+	return client
+}
+
+func (server *DevServer) removeClient(client chan struct{}) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	delete(server.clients, client)
+}
+
+// Serve reload events from `ReloadPath`, and forward all other requests to
+// the wrapped handler, injecting the reload script into HTML responses.
+func (server *DevServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == server.ReloadPath {
+		server.serveEvents(w, r)
+		return
+	}
+
+	r = r.Clone(r.Context())
+	r.Header.Del("Accept-Encoding")
+	r.Header.Del("If-None-Match")
+
+	recorder := &devResponseRecorder{header: http.Header{}}
+	server.Handler.ServeHTTP(recorder, r)
+
+	header := w.Header()
+	for key, values := range recorder.header {
+		header[key] = values
+	}
+	header.Del("ETag")
+	header.Del("Content-Length")
+	header.Set("Cache-Control", "no-store")
+
+	body := recorder.body.Bytes()
+	if strings.HasPrefix(header.Get("Content-Type"), "text/html") {
+		body = injectBeforeBodyEnd(body, server.reloadScript())
+	}
+
+	if recorder.status == 0 {
+		recorder.status = http.StatusOK
+	}
+	w.WriteHeader(recorder.status)
+	if _, err := w.Write(body); err != nil {
+		server.Logger.Println(err)
+	}
+}
+
+func (server *DevServer) serveEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	client := server.addClient()
+	defer server.removeClient(client)
+
+	header := w.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(": connected\n\n"))
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-client:
+			if _, err := w.Write([]byte("data: reload\n\n")); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// Insert a string before the closing `body` tag of an HTML document, or at
+// the end if there is no closing `body` tag.
+func injectBeforeBodyEnd(html []byte, insertion string) []byte {
+	index := bytes.LastIndex(html, []byte("</body>"))
+	if index < 0 {
+		return append(html, insertion...)
+	}
+	result := make([]byte, 0, len(html)+len(insertion))
+	result = append(result, html[:index]...)
+	result = append(result, insertion...)
+	return append(result, html[index:]...)
+}
+
+// Watch the files in `WatchPaths` for changes until the context is cancelled,
+// calling `OnChange` and sending a reload event whenever they change. This is
+// called automatically by [DevServer.ListenAndServe].
+func (server *DevServer) Watch(ctx context.Context) {
+	interval := server.PollInterval
+	if interval <= 0 {
+		interval = 500 * time.Millisecond
+	}
+	snapshot := server.snapshotFiles()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			next := server.snapshotFiles()
+			if !sameFileSnapshots(snapshot, next) {
+				snapshot = next
+				if server.OnChange != nil {
+					server.OnChange()
+				}
+				server.Reload()
+			}
+		}
+	}
+}
+
+// The modification time and size of a watched file.
+type watchedFile struct {
+	modTime time.Time
+	size    int64
+}
+
+// Get the modification times and sizes of all files in `WatchPaths`.
+// Directories are walked recursively.
+func (server *DevServer) snapshotFiles() map[string]watchedFile {
+	snapshot := map[string]watchedFile{}
+	for _, root := range server.WatchPaths {
+		// Unreadable paths are skipped rather than returning an error, so the
+		// result of WalkDir can be ignored
+		filepath.WalkDir(root, func(
+			path string,
+			entry fs.DirEntry,
+			err error,
+		) error {
+			if err != nil || entry.IsDir() {
+				return nil
+			}
+			info, err := entry.Info()
+			if err == nil {
+				snapshot[path] = watchedFile{info.ModTime(), info.Size()}
+			}
+			return nil
+		})
+	}
+	return snapshot
+}
+
+func sameFileSnapshots(
+	a map[string]watchedFile,
+	b map[string]watchedFile,
+) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for path, file := range a {
+		other, ok := b[path]
+		if !ok || file.size != other.size || !file.modTime.Equal(other.modTime) {
+			return false
+		}
+	}
+	return true
+}
+
+// Start watching files and listen for HTTP requests on the given address.
+// This blocks until the server stops, and always returns a non-nil error.
+func (server *DevServer) ListenAndServe(addr string) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go server.Watch(ctx)
+	server.Logger.Printf("Development server listening on %s\n", addr)
+	return http.ListenAndServe(addr, server)
+}
+
+// A minimal [http.ResponseWriter] that buffers the response from the wrapped
+// handler so that it can be modified.
+type devResponseRecorder struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (recorder *devResponseRecorder) Header() http.Header {
+	return recorder.header
+}
+
+func (recorder *devResponseRecorder) Write(data []byte) (int, error) {
+	if recorder.status == 0 {
+		recorder.status = http.StatusOK
+	}
+	return recorder.body.Write(data)
+}
+
+func (recorder *devResponseRecorder) WriteHeader(status int) {
+	if recorder.status == 0 {
+		recorder.status = status
+	}
+}
@@ -0,0 +1,219 @@
+package smetana
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newTestDevServer() *DevServer {
+	handler := newTestHandler()
+	handler.Gzip = true
+	handler.Page("/page", func(r *http.Request) Node {
+		return Html(Body("Hello"))
+	})
+	server := NewDevServer(handler)
+	server.Logger = log.New(io.Discard, "", 0)
+	return server
+}
+
+func TestDevServerInjectsReloadScript(t *testing.T) {
+	server := newTestDevServer()
+	request := httptest.NewRequest("GET", "/page", nil)
+	request.Header.Set("Accept-Encoding", "gzip")
+	response := serveTestRequest(server, request)
+	assertEqual(t, http.StatusOK, response.StatusCode)
+	assertEqual(t, "", response.Header.Get("Content-Encoding"))
+	assertEqual(t, "", response.Header.Get("ETag"))
+	assertEqual(
+		t,
+		"<!DOCTYPE html>\n<html><body>Hello"+server.reloadScript()+"</body></html>",
+		readTestBody(t, response),
+	)
+}
+
+func TestDevServerDoesNotModifyOtherResponses(t *testing.T) {
+	server := newTestDevServer()
+	response := serveTestRequest(
+		server,
+		httptest.NewRequest("GET", "/styles/light.css", nil),
+	)
+	assertEqual(t, "body{background:#FFFFFF;}", readTestBody(t, response))
+	response = serveTestRequest(server, httptest.NewRequest("GET", "/x", nil))
+	assertEqual(t, http.StatusNotFound, response.StatusCode)
+}
+
+func TestInjectBeforeBodyEnd(t *testing.T) {
+	assertEqual(
+		t,
+		"<body>a<script></script></body>",
+		string(injectBeforeBodyEnd([]byte("<body>a</body>"), "<script></script>")),
+	)
+	assertEqual(
+		t,
+		"<div></div><script></script>",
+		string(injectBeforeBodyEnd([]byte("<div></div>"), "<script></script>")),
+	)
+}
+
+func TestDevServerSendsReloadEvents(t *testing.T) {
+	server := newTestDevServer()
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	response, err := http.Get(httpServer.URL + server.ReloadPath)
+	assertEqual(t, nil, err)
+	defer response.Body.Close()
+	assertEqual(t, "text/event-stream", response.Header.Get("Content-Type"))
+
+	reader := bufio.NewReader(response.Body)
+	line, err := reader.ReadString('\n')
+	assertEqual(t, nil, err)
+	assertEqual(t, ": connected\n", line)
+	_, err = reader.ReadString('\n')
+	assertEqual(t, nil, err)
+
+	server.Reload()
+	line, err = reader.ReadString('\n')
+	assertEqual(t, nil, err)
+	assertEqual(t, "data: reload\n", line)
+}
+
+func TestDevServerWatchesFiles(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "styles.json")
+	assertEqual(t, nil, os.WriteFile(file, []byte("{}"), 0644))
+
+	changed := make(chan struct{}, 1)
+	server := newTestDevServer()
+	server.WatchPaths = []string{dir}
+	server.PollInterval = 10 * time.Millisecond
+	server.OnChange = func() {
+		changed <- struct{}{}
+	}
+	client := server.addClient()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go server.Watch(ctx)
+
+	time.Sleep(50 * time.Millisecond)
+	later := time.Now().Add(time.Minute)
+	assertEqual(t, nil, os.Chtimes(file, later, later))
+
+	select {
+	case <-changed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expecting OnChange to be called")
+	}
+	select {
+	case <-client:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expecting a reload event")
+	}
+}
+
+func TestDevServerDefaultsToOkStatus(t *testing.T) {
+	server := NewDevServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	response := serveTestRequest(server, httptest.NewRequest("GET", "/", nil))
+	assertEqual(t, http.StatusOK, response.StatusCode)
+	assertEqual(t, "", readTestBody(t, response))
+
+	recorder := &devResponseRecorder{header: http.Header{}}
+	recorder.Write([]byte("Hello"))
+	recorder.WriteHeader(http.StatusInternalServerError)
+	assertEqual(t, http.StatusOK, recorder.status)
+}
+
+func TestDevServerLogsWriteErrors(t *testing.T) {
+	var target bytes.Buffer
+	server := newTestDevServer()
+	server.Logger = log.New(&target, "", 0)
+	request := httptest.NewRequest("GET", "/page", nil)
+	server.ServeHTTP(&failingResponseWriter{http.Header{}}, request)
+	assertEqual(t, io.ErrClosedPipe.Error()+"\n", target.String())
+}
+
+func TestDevServerRequiresFlusherForEvents(t *testing.T) {
+	server := newTestDevServer()
+	writer := &failingResponseWriter{http.Header{}}
+	server.ServeHTTP(writer, httptest.NewRequest("GET", server.ReloadPath, nil))
+	assertEqual(t, 0, len(server.clients))
+}
+
+// A [httptest.ResponseRecorder] for an event stream that fails to write
+// events, as if the browser had disconnected.
+type disconnectedEventWriter struct {
+	*httptest.ResponseRecorder
+}
+
+func (w disconnectedEventWriter) Write(data []byte) (int, error) {
+	if bytes.HasPrefix(data, []byte("data:")) {
+		return 0, io.ErrClosedPipe
+	}
+	return w.ResponseRecorder.Write(data)
+}
+
+func TestDevServerStopsSendingEventsAfterWriteErrors(t *testing.T) {
+	server := newTestDevServer()
+	client := server.addClient()
+	done := make(chan struct{})
+	go func() {
+		writer := disconnectedEventWriter{httptest.NewRecorder()}
+		server.serveEvents(writer, httptest.NewRequest("GET", server.ReloadPath, nil))
+		close(done)
+	}()
+
+	// Wait for the event stream to connect before sending a reload
+	for connected := false; !connected; {
+		time.Sleep(time.Millisecond)
+		server.mutex.Lock()
+		connected = len(server.clients) == 2
+		server.mutex.Unlock()
+	}
+	server.Reload()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expecting the event stream to stop")
+	}
+
+	// Reloading doesn't block if a client hasn't received the last event
+	server.Reload()
+	assertEqual(t, 1, len(client))
+}
+
+func TestDevServerWatchUsesDefaultPollInterval(t *testing.T) {
+	server := newTestDevServer()
+	server.PollInterval = 0
+	server.WatchPaths = []string{filepath.Join(t.TempDir(), "missing")}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	server.Watch(ctx)
+	assertEqual(t, map[string]watchedFile{}, server.snapshotFiles())
+}
+
+func TestSameFileSnapshots(t *testing.T) {
+	file := watchedFile{time.Now(), 10}
+	a := map[string]watchedFile{"a": file}
+	assertEqual(t, true, sameFileSnapshots(a, map[string]watchedFile{"a": file}))
+	assertEqual(t, false, sameFileSnapshots(a, map[string]watchedFile{}))
+	assertEqual(t, false, sameFileSnapshots(a, map[string]watchedFile{"b": file}))
+}
+
+func TestDevServerListenAndServeReturnsErrors(t *testing.T) {
+	var target bytes.Buffer
+	server := newTestDevServer()
+	server.Logger = log.New(&target, "", 0)
+	err := server.ListenAndServe("invalid address")
+	assertNotEqual(t, nil, err)
+	assertEqual(t, "Development server listening on invalid address\n", target.String())
+}
@@ -13,9 +13,11 @@ type DomNode struct {
 
 // Convert a [DomNode] to HTML.
 func (node DomNode) ToHtml(builder *Builder) {
+	builder.path = append(builder.path, node.Tag)
+
 	if node.errors != nil {
 		for _, err := range node.errors {
-			builder.Logger.Println(err)
+			builder.ReportError(err)
 		}
 	}
 
@@ -26,6 +28,8 @@ func (node DomNode) ToHtml(builder *Builder) {
 		builder.writeChildren(node.Children)
 		builder.writeClosingTag(node.Tag)
 	}
+
+	builder.path = builder.path[:len(builder.path)-1]
 }
 
 // Assign new attributes to a [DomNode]. These values are merged
@@ -0,0 +1,154 @@
+package smetana
+
+import "fmt"
+
+// An error or panic that occurred while rendering the child of an
+// [ErrorBoundaryNode]. The path lists the tags from the root of the tree to
+// the node that failed, along with the type of the boundary's child (ie;
+// "html > body > main.ProfileCard > div").
+type RenderError struct {
+	Path string
+	Err  error
+}
+
+// Get a [RenderError] as a string.
+func (err RenderError) Error() string {
+	return fmt.Sprintf("Error rendering %s: %v", err.Path, err.Err)
+}
+
+// Get the underlying error of a [RenderError].
+func (err RenderError) Unwrap() error {
+	return err.Err
+}
+
+type boundaryState struct {
+	errors []error
+}
+
+// A [Node] that stops errors in its child from breaking the rest of the
+// page. The child is rendered into a scratch buffer, and if it panics or
+// reports an error with [Builder.ReportError] (including errors from
+// [NewDomNode] and [AsyncNode]s) then the `Fallback` is rendered instead and
+// the error is logged with the [Builder]'s `Logger` as a [RenderError].
+// Create an [ErrorBoundaryNode] with [ErrorBoundary]:
+//
+//	ErrorBoundary(P("Comments are unavailable"), CommentList{postId})
+//
+// The fallback may be nil to render nothing when the child fails.
+//
+// When rendered with [RenderHtmlContext], any [AsyncNode]s in the child are
+// computed concurrently with the rest of the page, and the child or the
+// fallback is chosen once they have finished. A [SuspenseNode] in the child
+// is streamed as normal by [RenderHtmlStream], so errors in its content are
+// handled by its own fallback rather than by the boundary.
+type ErrorBoundaryNode struct {
+	Fallback Node
+	Child    Node
+}
+
+// Create an [ErrorBoundaryNode] with a fallback and a child.
+func ErrorBoundary(fallback Node, child Node) ErrorBoundaryNode {
+	return ErrorBoundaryNode{fallback, child}
+}
+
+// Convert an [ErrorBoundaryNode] to HTML. If the child contains any
+// [AsyncNode]s then a segment is reserved in the output in the same way as
+// for an [AsyncNode], and it's resolved to the child or the fallback when
+// they have finished.
+func (node ErrorBoundaryNode) ToHtml(b *Builder) {
+	path := append([]string{}, b.path...)
+	scratch := Builder{
+		DeterministicAttributes: b.DeterministicAttributes,
+		Logger:                  b.Logger,
+		ctx:                     b.ctx,
+		stream:                  b.stream,
+		boundary:                &boundaryState{},
+		path:                    append(path, fmt.Sprintf("%T", node.Child)),
+	}
+	if b.async != nil {
+		scratch.async = &asyncState{renderer: b.async.renderer}
+	}
+
+	if err := scratch.renderBoundary(node.Child); err != nil {
+		b.Logger.Println(err)
+		writeOptionalNode(b, node.Fallback)
+		return
+	}
+	if scratch.async == nil || len(scratch.async.segments) == 0 {
+		b.Buf.WriteString(scratch.Buf.String())
+		return
+	}
+
+	segment := asyncSegment{
+		prefix:   b.Buf.String(),
+		fallback: node.Fallback,
+		result:   make(chan asyncResult, 1),
+	}
+	b.Buf.Reset()
+	b.async.segments = append(b.async.segments, segment)
+	parent := b.async.renderer.newBuilder(b)
+	go func() {
+		segment.result <- resolveBoundary(&scratch, node.Fallback, &parent)
+	}()
+}
+
+// Render the child of an [ErrorBoundaryNode], recovering from any panics.
+// The first error is returned as a [RenderError].
+func (builder *Builder) renderBoundary(child Node) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = RenderError{
+				builder.pathString(),
+				fmt.Errorf("panic: %v", recovered),
+			}
+		}
+	}()
+
+	if child != nil {
+		child.ToHtml(builder)
+	}
+	return builder.boundaryError()
+}
+
+// Get the first error reported to a [Builder] inside an [ErrorBoundaryNode],
+// or nil if there were none or the [Builder] isn't inside a boundary.
+func (builder *Builder) boundaryError() error {
+	if builder.boundary == nil || len(builder.boundary.errors) == 0 {
+		return nil
+	}
+	return builder.boundary.errors[0]
+}
+
+// Wait for the [AsyncNode]s in the child of an [ErrorBoundaryNode] to finish,
+// and render the fallback instead if any of them failed. As this runs in a
+// separate goroutine, panics in the fallback are recovered and returned as
+// errors.
+func resolveBoundary(
+	scratch *Builder,
+	fallback Node,
+	parent *Builder,
+) (result asyncResult) {
+	html, err := scratch.collectAsync()
+	if err == nil {
+		return asyncResult{html, nil}
+	}
+	parent.Logger.Println(RenderError{scratch.pathString(), err})
+	if fallback == nil {
+		return asyncResult{}
+	}
+
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			result = asyncResult{"", RenderError{
+				parent.pathString(),
+				fmt.Errorf("panic: %v", recovered),
+			}}
+		}
+	}()
+	fallback.ToHtml(parent)
+	html, err = parent.collectAsync()
+	if err == nil {
+		err = parent.boundaryError()
+	}
+	return asyncResult{html, err}
+}
@@ -0,0 +1,253 @@
+package smetana
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"strings"
+	"testing"
+	"time"
+)
+
+type panickingNode struct{}
+
+func (node panickingNode) ToHtml(b *Builder) {
+	b.Buf.WriteString("partial")
+	panic("boom")
+}
+
+type failingNode struct {
+	err error
+}
+
+func (node failingNode) ToHtml(b *Builder) {
+	b.ReportError(node.err)
+}
+
+func TestErrorBoundaryRendersChild(t *testing.T) {
+	node := Div(ErrorBoundary(P("Fallback"), P("Child")))
+	assertEqual(t, "<div><p>Child</p></div>", RenderHtml(node))
+}
+
+func TestErrorBoundaryRecoversPanics(t *testing.T) {
+	var target strings.Builder
+	logger := log.New(&target, "", 0)
+	node := Main(
+		ErrorBoundary(P("Fallback"), Section(Ul(Li(panickingNode{})))),
+		P("After"),
+	)
+	assertEqual(
+		t,
+		"<main><p>Fallback</p><p>After</p></main>",
+		RenderHtmlOpts(node, false, logger),
+	)
+	assertEqual(
+		t,
+		"Error rendering main > smetana.DomNode > section > ul > li: panic: boom\n",
+		target.String(),
+	)
+}
+
+func TestErrorBoundaryCatchesReportedErrors(t *testing.T) {
+	var target strings.Builder
+	logger := log.New(&target, "", 0)
+	err := errors.New("failed")
+	node := Div(ErrorBoundary(nil, Span(failingNode{err})), "ok")
+	assertEqual(t, "<div>ok</div>", RenderHtmlOpts(node, false, logger))
+	assertEqual(
+		t,
+		"Error rendering div > smetana.DomNode > span: failed\n",
+		target.String(),
+	)
+}
+
+func TestErrorBoundaryCatchesDomNodeErrors(t *testing.T) {
+	var target strings.Builder
+	logger := log.New(&target, "", 0)
+	node := ErrorBoundary(Text("Fallback"), Div(3.14))
+	assertEqual(t, "Fallback", RenderHtmlOpts(node, false, logger))
+	assertNotEqual(t, "", target.String())
+}
+
+func TestReportedErrorsAreLoggedOutsideBoundaries(t *testing.T) {
+	var target strings.Builder
+	logger := log.New(&target, "", 0)
+	node := Div(failingNode{errors.New("failed")}, "ok")
+	assertEqual(t, "<div>ok</div>", RenderHtmlOpts(node, false, logger))
+	assertEqual(t, "failed\n", target.String())
+}
+
+func TestNestedErrorBoundaries(t *testing.T) {
+	var target strings.Builder
+	logger := log.New(&target, "", 0)
+	node := ErrorBoundary(
+		Text("Outer"),
+		Div(ErrorBoundary(Text("Inner"), panickingNode{}), "ok"),
+	)
+	assertEqual(t, "<div>Innerok</div>", RenderHtmlOpts(node, false, logger))
+}
+
+func TestErrorBoundaryWithAsyncNodes(t *testing.T) {
+	var target strings.Builder
+	logger := log.New(&target, "", 0)
+	failure := errors.New("failed")
+	node := Div(
+		ErrorBoundary(Text("Fallback"), Async(func(ctx context.Context) (Node, error) {
+			return nil, failure
+		})),
+		ErrorBoundary(Text("Fallback"), asyncText("a", 0)),
+		ErrorBoundary(Text("Panic"), Async(func(ctx context.Context) (Node, error) {
+			panic("boom")
+		})),
+	)
+	result, err := RenderHtmlContextOpts(context.Background(), node, 0, false, logger)
+	assertEqual(t, nil, err)
+	assertEqual(t, "<div>FallbackaPanic</div>", result)
+	assertEqual(t, "<div>FallbackaPanic</div>", RenderHtmlOpts(node, false, logger))
+}
+
+func TestAsyncPanicsAreRecovered(t *testing.T) {
+	node := Div(Async(func(ctx context.Context) (Node, error) {
+		panic("boom")
+	}).WithFallback(Text("Fallback")))
+	result, err := RenderHtmlContext(context.Background(), node)
+	assertEqual(t, "<div>Fallback</div>", result)
+	assertEqual(t, "Error rendering div: panic: boom", err.Error())
+}
+
+func TestErrorBoundariesDoNotBlockAsyncNodes(t *testing.T) {
+	node := Div(
+		ErrorBoundary(nil, asyncText("a", 100*time.Millisecond)),
+		ErrorBoundary(nil, asyncText("b", 100*time.Millisecond)),
+		ErrorBoundary(nil, asyncText("c", 100*time.Millisecond)),
+	)
+	start := time.Now()
+	result, err := RenderHtmlContext(context.Background(), node)
+	assertEqual(t, nil, err)
+	assertEqual(t, "<div>abc</div>", result)
+	assertEqual(t, true, time.Since(start) < 250*time.Millisecond)
+}
+
+func TestErrorBoundaryFallbackCanBeAsync(t *testing.T) {
+	var target strings.Builder
+	logger := log.New(&target, "", 0)
+	node := Div(
+		P("Before"),
+		ErrorBoundary(
+			Span(asyncText("Fallback", 0)),
+			Async(func(ctx context.Context) (Node, error) {
+				return nil, errors.New("failed")
+			}),
+		),
+		P("After"),
+	)
+	result, err := RenderHtmlContextOpts(context.Background(), node, 0, false, logger)
+	assertEqual(t, nil, err)
+	assertEqual(t, "<div><p>Before</p><span>Fallback</span><p>After</p></div>", result)
+	assertEqual(t, "Error rendering div > smetana.AsyncNode: failed\n", target.String())
+}
+
+func TestErrorBoundaryCatchesErrorsReportedInAsyncContent(t *testing.T) {
+	var target strings.Builder
+	logger := log.New(&target, "", 0)
+	node := Div(ErrorBoundary(
+		P("Fallback"),
+		Async(func(ctx context.Context) (Node, error) {
+			return Div(Span(asyncText("nested", 0), struct{}{})), nil
+		}),
+	))
+	result, err := RenderHtmlContextOpts(context.Background(), node, 0, false, logger)
+	assertEqual(t, nil, err)
+	assertEqual(t, "<div><p>Fallback</p></div>", result)
+	assertEqual(
+		t,
+		"Error rendering div > smetana.AsyncNode: Error rendering div > "+
+			"smetana.AsyncNode > div > span: Invalid DomNode argument: {}\n",
+		target.String(),
+	)
+}
+
+func TestErrorsInAsyncFallbacksAreCaughtByOuterBoundaries(t *testing.T) {
+	failed := Async(func(ctx context.Context) (Node, error) {
+		return nil, errors.New("failed")
+	})
+	node := Div(ErrorBoundary(
+		P("Outer"),
+		ErrorBoundary(failingNode{errors.New("fallback failed")}, failed),
+	))
+	result, err := RenderHtmlContextOpts(
+		context.Background(),
+		node,
+		0,
+		false,
+		log.New(io.Discard, "", 0),
+	)
+	assertEqual(t, nil, err)
+	assertEqual(t, "<div><p>Outer</p></div>", result)
+
+	node = Div(ErrorBoundary(nil, failed), P("After"))
+	result, err = RenderHtmlContextOpts(
+		context.Background(),
+		node,
+		0,
+		false,
+		log.New(io.Discard, "", 0),
+	)
+	assertEqual(t, nil, err)
+	assertEqual(t, "<div><p>After</p></div>", result)
+
+	node = Div(ErrorBoundary(panickingNode{}, failed))
+	result, err = RenderHtmlContextOpts(
+		context.Background(),
+		node,
+		0,
+		false,
+		log.New(io.Discard, "", 0),
+	)
+	assertEqual(t, "Error rendering div: panic: boom", err.Error())
+	assertEqual(t, "<div></div>", result)
+}
+
+type flushingNode struct{}
+
+func (node flushingNode) ToHtml(b *Builder) {
+	b.Buf.WriteString("flushed")
+	b.Flush()
+}
+
+func TestFlushInsideErrorBoundaryDoesNothing(t *testing.T) {
+	node := Div(P("before"), ErrorBoundary(nil, flushingNode{}), P("after"))
+	var w chunkWriter
+	assertEqual(t, nil, RenderHtmlStream(context.Background(), &w, node))
+	assertEqual(t, []string{"<div><p>before</p>flushed<p>after</p></div>"}, w.chunks)
+
+	node = Div(flushingNode{}, ErrorBoundary(nil, P("after")))
+	w = chunkWriter{}
+	assertEqual(t, nil, RenderHtmlStream(context.Background(), &w, node))
+	assertEqual(t, []string{"<div>flushed", "<p>after</p></div>"}, w.chunks)
+}
+
+func TestSuspenseInsideErrorBoundaryIsStreamed(t *testing.T) {
+	release := make(chan struct{})
+	node := Div(ErrorBoundary(
+		P("Fallback"),
+		Suspense(P("Loading..."), func(ctx context.Context) (Node, error) {
+			<-release
+			return P("Content"), nil
+		}),
+	))
+	var w chunkWriter
+	done := make(chan error)
+	go func() {
+		done <- RenderHtmlStream(context.Background(), &w, node)
+	}()
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	assertEqual(t, nil, <-done)
+	assertEqual(t, []string{
+		"<div><div id=\"smetana-placeholder-0\" style=\"display:contents\">" +
+			"<p>Loading...</p></div></div>",
+		streamedContent("0", "<p>Content</p>", streamSwapScript),
+	}, w.chunks)
+}
@@ -0,0 +1,229 @@
+package smetana
+
+import (
+	"strings"
+	"time"
+)
+
+// A single entry in a [Feed]. `Content` is optional and, if provided, is
+// rendered to HTML and embedded in the feed in a CDATA section. If `Id` is
+// empty then `Link` is used as the unique identifier of the item (Atom
+// requires one, so an item with neither is logged as an error). If
+// `Updated` is zero then `Published` is used in its place.
+type FeedItem struct {
+	Title       string
+	Link        string
+	Id          string
+	Description string
+	Content     Node
+	Author      string
+	Published   time.Time
+	Updated     time.Time
+}
+
+// [Feed] represents a syndication feed which can be rendered to either an
+// RSS 2.0 document (https://www.rssboard.org/rss-specification) with the
+// [ToRss] method or an Atom 1.0 document (RFC 4287) with the [ToAtom] method.
+// `Link` is the URL of the website the feed belongs to and `FeedUrl` is the
+// URL of the feed itself.
+type Feed struct {
+	Title       string
+	Link        string
+	FeedUrl     string
+	Description string
+	Author      string
+	Language    string
+	Updated     time.Time
+	Items       []FeedItem
+}
+
+// Create a new [Feed] with the given title, website link and description.
+func NewFeed(title string, link string, description string) Feed {
+	return Feed{
+		Title:       title,
+		Link:        link,
+		Description: description,
+		Items:       []FeedItem{},
+	}
+}
+
+// Add an item to the end of a [Feed].
+func (feed *Feed) AddItem(item FeedItem) {
+	feed.Items = append(feed.Items, item)
+}
+
+func (item FeedItem) id() string {
+	if len(item.Id) > 0 {
+		return item.Id
+	}
+	return item.Link
+}
+
+func (item FeedItem) updated() time.Time {
+	if item.Updated.IsZero() {
+		return item.Published
+	}
+	return item.Updated
+}
+
+// Get the last updated time of a [Feed]. This is `Updated` if it is set,
+// otherwise the latest update time of any item.
+func (feed Feed) updated() time.Time {
+	if !feed.Updated.IsZero() {
+		return feed.Updated
+	}
+	var result time.Time
+	for _, item := range feed.Items {
+		if updated := item.updated(); updated.After(result) {
+			result = updated
+		}
+	}
+	return result
+}
+
+var xmlEscaper = strings.NewReplacer(
+	"&", "&amp;",
+	"<", "&lt;",
+	">", "&gt;",
+	"\"", "&quot;",
+	"'", "&apos;",
+)
+
+func (builder *Builder) writeXmlText(text string) {
+	xmlEscaper.WriteString(&builder.Buf, text)
+}
+
+func (builder *Builder) writeXmlElement(tag Tag, text string) {
+	builder.Buf.WriteByte('<')
+	builder.Buf.WriteString(tag)
+	builder.Buf.WriteByte('>')
+	builder.writeXmlText(text)
+	builder.writeClosingTag(tag)
+}
+
+func (builder *Builder) writeOptionalXmlElement(tag Tag, text string) {
+	if len(text) > 0 {
+		builder.writeXmlElement(tag, text)
+	}
+}
+
+// Write a CDATA section. Any "]]>" sequences in the text are split across
+// multiple sections so that the text can't terminate the CDATA early.
+func (builder *Builder) writeCdata(text string) {
+	builder.Buf.WriteString("<![CDATA[")
+	builder.Buf.WriteString(strings.ReplaceAll(text, "]]>", "]]]]><![CDATA[>"))
+	builder.Buf.WriteString("]]>")
+}
+
+// Convert a [Feed] to an RSS 2.0 XML string.
+func (feed Feed) ToRss(builder *Builder) {
+	builder.Buf.WriteString("<?xml version=\"1.0\" encoding=\"UTF-8\"?>")
+	builder.Buf.WriteString("<rss version=\"2.0\" xmlns:atom=\"http://www.w3.org/2005/Atom\" xmlns:content=\"http://purl.org/rss/1.0/modules/content/\">")
+	builder.Buf.WriteString("<channel>")
+	builder.writeXmlElement("title", feed.Title)
+	builder.writeXmlElement("link", feed.Link)
+	builder.writeXmlElement("description", feed.Description)
+	builder.writeOptionalXmlElement("language", feed.Language)
+	if updated := feed.updated(); !updated.IsZero() {
+		builder.writeXmlElement("lastBuildDate", updated.Format(time.RFC1123Z))
+	}
+	if len(feed.FeedUrl) > 0 {
+		builder.Buf.WriteString("<atom:link href=\"")
+		builder.writeXmlText(feed.FeedUrl)
+		builder.Buf.WriteString("\" rel=\"self\" type=\"application/rss+xml\"/>")
+	}
+	for _, item := range feed.Items {
+		builder.Buf.WriteString("<item>")
+		builder.writeOptionalXmlElement("title", item.Title)
+		builder.writeOptionalXmlElement("link", item.Link)
+		if id := item.id(); len(id) > 0 {
+			if id == item.Link {
+				builder.Buf.WriteString("<guid>")
+			} else {
+				builder.Buf.WriteString("<guid isPermaLink=\"false\">")
+			}
+			builder.writeXmlText(id)
+			builder.writeClosingTag("guid")
+		}
+		builder.writeOptionalXmlElement("description", item.Description)
+		if item.Content != nil {
+			builder.Buf.WriteString("<content:encoded>")
+			content, _ := builder.renderNode(item.Content)
+			builder.writeCdata(content)
+			builder.writeClosingTag("content:encoded")
+		}
+		builder.writeOptionalXmlElement("author", item.Author)
+		if !item.Published.IsZero() {
+			builder.writeXmlElement("pubDate", item.Published.Format(time.RFC1123Z))
+		}
+		builder.writeClosingTag("item")
+	}
+	builder.Buf.WriteString("</channel></rss>")
+}
+
+func (builder *Builder) writeAtomLink(rel string, href string) {
+	builder.Buf.WriteString("<link rel=\"")
+	builder.Buf.WriteString(rel)
+	builder.Buf.WriteString("\" href=\"")
+	builder.writeXmlText(href)
+	builder.Buf.WriteString("\"/>")
+}
+
+func (builder *Builder) writeAtomAuthor(author string) {
+	if len(author) > 0 {
+		builder.Buf.WriteString("<author>")
+		builder.writeXmlElement("name", author)
+		builder.writeClosingTag("author")
+	}
+}
+
+// Convert a [Feed] to an Atom 1.0 XML string.
+func (feed Feed) ToAtom(builder *Builder) {
+	builder.Buf.WriteString("<?xml version=\"1.0\" encoding=\"UTF-8\"?>")
+	builder.Buf.WriteString("<feed xmlns=\"http://www.w3.org/2005/Atom\"")
+	if len(feed.Language) > 0 {
+		builder.Buf.WriteString(" xml:lang=\"")
+		builder.writeXmlText(feed.Language)
+		builder.Buf.WriteByte('"')
+	}
+	builder.Buf.WriteByte('>')
+	builder.writeXmlElement("title", feed.Title)
+	builder.writeOptionalXmlElement("subtitle", feed.Description)
+	if len(feed.FeedUrl) > 0 {
+		builder.writeXmlElement("id", feed.FeedUrl)
+	} else {
+		builder.writeXmlElement("id", feed.Link)
+	}
+	builder.writeAtomLink("alternate", feed.Link)
+	if len(feed.FeedUrl) > 0 {
+		builder.writeAtomLink("self", feed.FeedUrl)
+	}
+	builder.writeXmlElement("updated", feed.updated().Format(time.RFC3339))
+	builder.writeAtomAuthor(feed.Author)
+	for _, item := range feed.Items {
+		builder.Buf.WriteString("<entry>")
+		builder.writeXmlElement("title", item.Title)
+		if id := item.id(); len(id) > 0 {
+			builder.writeXmlElement("id", id)
+		} else {
+			builder.Logger.Printf("Atom feed item %q has no Id or Link\n", item.Title)
+		}
+		if len(item.Link) > 0 {
+			builder.writeAtomLink("alternate", item.Link)
+		}
+		builder.writeXmlElement("updated", item.updated().Format(time.RFC3339))
+		if !item.Published.IsZero() {
+			builder.writeXmlElement("published", item.Published.Format(time.RFC3339))
+		}
+		builder.writeAtomAuthor(item.Author)
+		builder.writeOptionalXmlElement("summary", item.Description)
+		if item.Content != nil {
+			builder.Buf.WriteString("<content type=\"html\">")
+			content, _ := builder.renderNode(item.Content)
+			builder.writeCdata(content)
+			builder.writeClosingTag("content")
+		}
+		builder.writeClosingTag("entry")
+	}
+	builder.writeClosingTag("feed")
+}
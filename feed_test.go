@@ -0,0 +1,128 @@
+package smetana
+
+import (
+	"log"
+	"strings"
+	"testing"
+	"time"
+)
+
+func createTestFeed(t *testing.T) Feed {
+	published, err := time.Parse(time.RFC3339, "2022-02-03T12:00:00Z")
+	assertEqual(t, nil, err)
+	updated, err := time.Parse(time.RFC3339, "2022-02-04T12:00:00Z")
+	assertEqual(t, nil, err)
+	feed := NewFeed("Tom & Jerry", "https://example.com", "A <blog>")
+	feed.FeedUrl = "https://example.com/feed.xml"
+	feed.AddItem(FeedItem{
+		Title:     "First post",
+		Link:      "https://example.com/first",
+		Content:   P("Hello ]]> world"),
+		Author:    "jerry@example.com",
+		Published: published,
+		Updated:   updated,
+	})
+	feed.AddItem(FeedItem{
+		Title:       "Second post",
+		Id:          "post-2",
+		Description: "Short & sweet",
+		Published:   published,
+	})
+	return feed
+}
+
+func TestCreateFeed(t *testing.T) {
+	feed := NewFeed("Title", "https://example.com", "Description")
+	assertEqual(t, "Title", feed.Title)
+	assertEqual(t, "https://example.com", feed.Link)
+	assertEqual(t, "Description", feed.Description)
+	assertEqual(t, 0, len(feed.Items))
+	feed.AddItem(FeedItem{Title: "Item"})
+	assertEqual(t, 1, len(feed.Items))
+}
+
+func TestFeedUpdatedDefaultsToLatestItem(t *testing.T) {
+	feed := createTestFeed(t)
+	assertEqual(t, feed.Items[0].Updated, feed.updated())
+	feed.Updated = feed.Items[0].Published
+	assertEqual(t, feed.Items[0].Published, feed.updated())
+}
+
+func TestWriteCdataEscapesTerminator(t *testing.T) {
+	builder := Builder{}
+	builder.writeCdata("a]]>b")
+	assertEqual(t, "<![CDATA[a]]]]><![CDATA[>b]]>", builder.Buf.String())
+}
+
+func TestCanRenderRss(t *testing.T) {
+	expected := "<?xml version=\"1.0\" encoding=\"UTF-8\"?>" +
+		"<rss version=\"2.0\" xmlns:atom=\"http://www.w3.org/2005/Atom\" xmlns:content=\"http://purl.org/rss/1.0/modules/content/\">" +
+		"<channel><title>Tom &amp; Jerry</title><link>https://example.com</link>" +
+		"<description>A &lt;blog&gt;</description>" +
+		"<lastBuildDate>Fri, 04 Feb 2022 12:00:00 +0000</lastBuildDate>" +
+		"<atom:link href=\"https://example.com/feed.xml\" rel=\"self\" type=\"application/rss+xml\"/>" +
+		"<item><title>First post</title><link>https://example.com/first</link>" +
+		"<guid>https://example.com/first</guid>" +
+		"<content:encoded><![CDATA[<p>Hello ]]]]><![CDATA[> world</p>]]></content:encoded>" +
+		"<author>jerry@example.com</author>" +
+		"<pubDate>Thu, 03 Feb 2022 12:00:00 +0000</pubDate></item>" +
+		"<item><title>Second post</title><guid isPermaLink=\"false\">post-2</guid>" +
+		"<description>Short &amp; sweet</description>" +
+		"<pubDate>Thu, 03 Feb 2022 12:00:00 +0000</pubDate></item>" +
+		"</channel></rss>"
+	assertEqual(t, expected, RenderRss(createTestFeed(t)))
+}
+
+func TestCanRenderAtom(t *testing.T) {
+	feed := createTestFeed(t)
+	feed.Author = "Tom"
+	feed.Language = "en"
+	expected := "<?xml version=\"1.0\" encoding=\"UTF-8\"?>" +
+		"<feed xmlns=\"http://www.w3.org/2005/Atom\" xml:lang=\"en\">" +
+		"<title>Tom &amp; Jerry</title><subtitle>A &lt;blog&gt;</subtitle>" +
+		"<id>https://example.com/feed.xml</id>" +
+		"<link rel=\"alternate\" href=\"https://example.com\"/>" +
+		"<link rel=\"self\" href=\"https://example.com/feed.xml\"/>" +
+		"<updated>2022-02-04T12:00:00Z</updated>" +
+		"<author><name>Tom</name></author>" +
+		"<entry><title>First post</title><id>https://example.com/first</id>" +
+		"<link rel=\"alternate\" href=\"https://example.com/first\"/>" +
+		"<updated>2022-02-04T12:00:00Z</updated>" +
+		"<published>2022-02-03T12:00:00Z</published>" +
+		"<author><name>jerry@example.com</name></author>" +
+		"<content type=\"html\"><![CDATA[<p>Hello ]]]]><![CDATA[> world</p>]]></content></entry>" +
+		"<entry><title>Second post</title><id>post-2</id>" +
+		"<updated>2022-02-03T12:00:00Z</updated>" +
+		"<published>2022-02-03T12:00:00Z</published>" +
+		"<summary>Short &amp; sweet</summary></entry>" +
+		"</feed>"
+	assertEqual(t, expected, RenderAtom(feed))
+}
+
+func TestCanRenderAtomWithoutFeedUrl(t *testing.T) {
+	feed := NewFeed("Title", "https://example.com", "")
+	feed.Updated = time.Date(2022, 2, 3, 0, 0, 0, 0, time.UTC)
+	expected := "<?xml version=\"1.0\" encoding=\"UTF-8\"?>" +
+		"<feed xmlns=\"http://www.w3.org/2005/Atom\">" +
+		"<title>Title</title><id>https://example.com</id>" +
+		"<link rel=\"alternate\" href=\"https://example.com\"/>" +
+		"<updated>2022-02-03T00:00:00Z</updated></feed>"
+	assertEqual(t, expected, RenderAtom(feed))
+}
+
+func TestAtomItemWithoutIdIsLogged(t *testing.T) {
+	var target strings.Builder
+	logger := log.New(&target, "", 0)
+	feed := NewFeed("Title", "https://example.com", "")
+	feed.Updated = time.Date(2022, 2, 3, 0, 0, 0, 0, time.UTC)
+	feed.AddItem(FeedItem{Title: "Untitled", Updated: feed.Updated})
+	expected := "<?xml version=\"1.0\" encoding=\"UTF-8\"?>" +
+		"<feed xmlns=\"http://www.w3.org/2005/Atom\">" +
+		"<title>Title</title><id>https://example.com</id>" +
+		"<link rel=\"alternate\" href=\"https://example.com\"/>" +
+		"<updated>2022-02-03T00:00:00Z</updated>" +
+		"<entry><title>Untitled</title>" +
+		"<updated>2022-02-03T00:00:00Z</updated></entry></feed>"
+	assertEqual(t, expected, RenderAtomOpts(feed, false, logger))
+	assertEqual(t, "Atom feed item \"Untitled\" has no Id or Link\n", target.String())
+}
@@ -0,0 +1,135 @@
+package smetana
+
+import "strings"
+
+// The type of a [CssGradient].
+type GradientKind string
+
+const (
+	// A `linear-gradient()` along a straight line.
+	GradientLinear GradientKind = "linear"
+	// A `radial-gradient()` radiating out from an origin.
+	GradientRadial GradientKind = "radial"
+	// A `conic-gradient()` rotating around a center point.
+	GradientConic GradientKind = "conic"
+)
+
+// A single color stop in a [CssGradient]. The color may be any type supported
+// by [CssValueToString], such as a [Color] or a [PaletteValue]. Each stop may
+// have zero, one or two positions (ie; `red 10% 20%`). Also see [Stop].
+type GradientStop struct {
+	Color     any
+	Positions []any
+}
+
+// Create a [GradientStop] with the given color and optional positions.
+func Stop(color any, positions ...any) GradientStop {
+	return GradientStop{color, positions}
+}
+
+// Convert a [GradientStop] into a CSS string.
+func (stop GradientStop) ToCssValue(palette Palette) (string, error) {
+	values := append([]any{stop.Color}, stop.Positions...)
+	return joinCssValues(palette, values, " ")
+}
+
+// Get all of the [PaletteValue]s referenced by a [GradientStop].
+func (stop GradientStop) PaletteValues() []PaletteValue {
+	values := cssValuePaletteValues(stop.Color)
+	for _, position := range stop.Positions {
+		values = append(values, cssValuePaletteValues(position)...)
+	}
+	return values
+}
+
+// A CSS gradient implementing [CssValue], for use in properties such as
+// `background-image`. Gradients are usually created with [LinearGradient],
+// [RadialGradient] or [ConicGradient]:
+//
+//	LinearGradient(
+//		Deg(45),
+//		Stop(PaletteValue("primary")),
+//		Stop(Hex("#fff"), Perc(80)),
+//	)
+//
+// will render to `linear-gradient(45deg, #3366CC, #FFFFFF 80%)` if "primary"
+// is `#3366CC` in the current palette.
+//
+// The optional `Direction` is written before the stops. For linear gradients
+// this is an angle or a side (ie; "to right"), for radial gradients it is the
+// shape, size and position (ie; "circle at top"), and for conic gradients it
+// is the starting angle, which is prefixed with "from".
+type CssGradient struct {
+	Kind      GradientKind
+	Repeating bool
+	Direction any
+	Stops     []GradientStop
+}
+
+// Create a `linear-gradient()`. The direction may be nil to use the default
+// (top to bottom).
+func LinearGradient(direction any, stops ...GradientStop) CssGradient {
+	return CssGradient{GradientLinear, false, direction, stops}
+}
+
+// Create a `radial-gradient()`. The shape may be nil to use the default
+// (an ellipse at the center).
+func RadialGradient(shape any, stops ...GradientStop) CssGradient {
+	return CssGradient{GradientRadial, false, shape, stops}
+}
+
+// Create a `conic-gradient()`. The starting angle may be nil to start from
+// the top.
+func ConicGradient(from any, stops ...GradientStop) CssGradient {
+	return CssGradient{GradientConic, false, from, stops}
+}
+
+// Convert a [CssGradient] into a repeating gradient (ie;
+// `repeating-linear-gradient()`).
+func (gradient CssGradient) Repeat() CssGradient {
+	gradient.Repeating = true
+	return gradient
+}
+
+// Convert a [CssGradient] into a CSS string.
+func (gradient CssGradient) ToCssValue(palette Palette) (string, error) {
+	var err error
+	var buf strings.Builder
+	if gradient.Repeating {
+		buf.WriteString("repeating-")
+	}
+	buf.WriteString(string(gradient.Kind))
+	buf.WriteString("-gradient(")
+	if gradient.Direction != nil {
+		if gradient.Kind == GradientConic {
+			buf.WriteString("from ")
+		}
+		var direction string
+		direction, err = CssValueToString(palette, gradient.Direction)
+		buf.WriteString(direction)
+		if len(gradient.Stops) > 0 {
+			buf.WriteString(", ")
+		}
+	}
+	for i, stop := range gradient.Stops {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		str, stopErr := stop.ToCssValue(palette)
+		if err == nil {
+			err = stopErr
+		}
+		buf.WriteString(str)
+	}
+	buf.WriteString(")")
+	return buf.String(), err
+}
+
+// Get all of the [PaletteValue]s referenced by a [CssGradient].
+func (gradient CssGradient) PaletteValues() []PaletteValue {
+	values := cssValuePaletteValues(gradient.Direction)
+	for _, stop := range gradient.Stops {
+		values = append(values, stop.PaletteValues()...)
+	}
+	return values
+}
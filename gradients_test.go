@@ -0,0 +1,85 @@
+package smetana
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRenderGradients(t *testing.T) {
+	palette := Palette{"primary": Hex("#3366CC"), "stop": Perc(40)}
+	tests := map[string]any{
+		"linear-gradient(#FF0000, #0000FF)": LinearGradient(
+			nil,
+			Stop(Hex("#f00")),
+			Stop(Hex("#00f")),
+		),
+		"linear-gradient(45deg, #3366CC, #FFFFFF 80%)": LinearGradient(
+			Deg(45),
+			Stop(PaletteValue("primary")),
+			Stop(Hex("#fff"), Perc(80)),
+		),
+		"linear-gradient(to right, red 0% 40%, blue 40%)": LinearGradient(
+			"to right",
+			Stop("red", Perc(0), PaletteValue("stop")),
+			Stop("blue", PaletteValue("stop")),
+		),
+		"radial-gradient(circle at top, #3366CC, transparent)": RadialGradient(
+			"circle at top",
+			Stop(PaletteValue("primary")),
+			Stop("transparent"),
+		),
+		"conic-gradient(from 0.25turn, red, blue)": ConicGradient(
+			Turn(0.25),
+			Stop("red"),
+			Stop("blue"),
+		),
+		"repeating-linear-gradient(red 0px, blue 10px)": LinearGradient(
+			nil,
+			Stop("red", 0),
+			Stop("blue", PX(10)),
+		).Repeat(),
+	}
+	for expected, value := range tests {
+		result, err := CssValueToString(palette, value)
+		assertEqual(t, nil, err)
+		assertEqual(t, expected, result)
+	}
+}
+
+func TestGradientsResolvePaletteValuesPerPalette(t *testing.T) {
+	smetana := NewSmetanaWithPalettes(Palettes{
+		"light": {"bg": Hex("#fff")},
+		"dark":  {"bg": Hex("#000")},
+	})
+	smetana.Styles.AddBlock("body", CssProps{{
+		"background-image",
+		LinearGradient(Deg(180), Stop(PaletteValue("bg")), Stop("transparent")),
+	}})
+	css := smetana.RenderStyles()
+	assertEqual(
+		t,
+		"body{background-image:linear-gradient(180deg, #FFFFFF, transparent);}",
+		css["light"],
+	)
+	assertEqual(
+		t,
+		"body{background-image:linear-gradient(180deg, #000000, transparent);}",
+		css["dark"],
+	)
+}
+
+func TestGradientsReportMissingPaletteValues(t *testing.T) {
+	value := LinearGradient(nil, Stop(PaletteValue("a")), Stop("red"))
+	result, err := CssValueToString(Palette{}, value)
+	assertEqual(t, "linear-gradient(inherit, red)", result)
+	assertEqual(t, errors.New("Missing palette value: a"), err)
+}
+
+func TestGradientPaletteValues(t *testing.T) {
+	value := ConicGradient(
+		PaletteValue("a"),
+		Stop(PaletteValue("b"), PaletteValue("c")),
+		Stop("red", Perc(50)),
+	)
+	assertEqual(t, []PaletteValue{"a", "b", "c"}, value.PaletteValues())
+}
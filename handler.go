@@ -0,0 +1,249 @@
+package smetana
+
+import (
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// A function that creates the [Node] for a page, usually an [HtmlNode], from
+// an incoming request.
+type PageFunc func(r *http.Request) Node
+
+// An [http.Handler] that serves pages created by [PageFunc]s along with the
+// stylesheets from a [Smetana] context. Each palette is served as a separate
+// stylesheet at `<StylesPath><palette>.css` (ie; "/styles/dark.css"). Create
+// a [Handler] with [NewHandler] and add pages with [Handler.Page]:
+//
+//	handler := NewHandler(&smetana)
+//	handler.Page("/", func(r *http.Request) Node {
+//		return Html(
+//			Head(LinkStylesheet(handler.StylesHref("default"))),
+//			Body("Hello world"),
+//		)
+//	})
+//	http.ListenAndServe(":8080", handler)
+//
+// Responses include an `ETag` header based on a hash of the rendered output,
+// and requests with a matching `If-None-Match` header are answered with
+// "304 Not Modified". If `Gzip` is true then responses are compressed for
+// clients that support it.
+//
+// Pages and styles are rendered for every request, so changes to the
+// [Smetana] context are reflected immediately. Pages are rendered with
+// [RenderHtmlContext] using the context of the request, so any [AsyncNode]s
+// are computed concurrently and are cancelled if the client disconnects.
+//
+// If `Stream` is true then pages are rendered with [RenderHtmlStream] instead,
+// so that the page is sent before any [SuspenseNode]s have finished. Streamed
+// pages don't have an `ETag` and aren't compressed.
+type Handler struct {
+	Smetana                 *Smetana
+	Pages                   map[string]PageFunc
+	NotFound                PageFunc
+	StylesPath              string
+	Gzip                    bool
+	Stream                  bool
+	DeterministicAttributes bool
+	Logger                  *log.Logger
+}
+
+// Create a new [Handler] for the given [Smetana] context with styles served
+// from "/styles/".
+func NewHandler(smetana *Smetana) *Handler {
+	return &Handler{
+		Smetana:    smetana,
+		Pages:      map[string]PageFunc{},
+		StylesPath: "/styles/",
+		Logger:     log.New(os.Stderr, "", 0),
+	}
+}
+
+// Add a page to a [Handler] at the given path. Paths are matched exactly.
+func (h *Handler) Page(path string, page PageFunc) {
+	h.Pages[path] = page
+}
+
+// Get the URL of the stylesheet for the given palette name.
+func (h *Handler) StylesHref(palette string) string {
+	return h.StylesPath + palette + ".css"
+}
+
+// Serve a page or stylesheet. Only GET and HEAD requests are supported.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if strings.HasPrefix(r.URL.Path, h.StylesPath) &&
+		strings.HasSuffix(r.URL.Path, ".css") {
+		name := strings.TrimSuffix(
+			strings.TrimPrefix(r.URL.Path, h.StylesPath),
+			".css",
+		)
+		if palette, ok := h.Smetana.Palettes[name]; ok {
+			css := RenderCssOpts(h.Smetana.Styles, palette, h.Logger)
+			h.serveContent(w, r, http.StatusOK, "text/css; charset=utf-8", css)
+			return
+		}
+	} else if page, ok := h.Pages[r.URL.Path]; ok {
+		h.servePage(w, r, http.StatusOK, page)
+		return
+	}
+
+	if h.NotFound != nil {
+		h.servePage(w, r, http.StatusNotFound, h.NotFound)
+	} else {
+		http.NotFound(w, r)
+	}
+}
+
+func (h *Handler) servePage(
+	w http.ResponseWriter,
+	r *http.Request,
+	status int,
+	page PageFunc,
+) {
+	if h.Stream {
+		h.streamPage(w, r, status, page)
+		return
+	}
+	html, err := RenderHtmlContextOpts(
+		r.Context(),
+		page(r),
+		0,
+		h.DeterministicAttributes,
+		h.Logger,
+	)
+	if err != nil {
+		h.Logger.Println(err)
+	}
+	h.serveContent(w, r, status, "text/html; charset=utf-8", html)
+}
+
+func (h *Handler) streamPage(
+	w http.ResponseWriter,
+	r *http.Request,
+	status int,
+	page PageFunc,
+) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	if r.Method == http.MethodHead {
+		return
+	}
+	err := RenderHtmlStreamOpts(
+		r.Context(),
+		w,
+		page(r),
+		0,
+		h.DeterministicAttributes,
+		h.Logger,
+	)
+	if err != nil {
+		h.Logger.Println(err)
+	}
+}
+
+func (h *Handler) serveContent(
+	w http.ResponseWriter,
+	r *http.Request,
+	status int,
+	contentType string,
+	content string,
+) {
+	useGzip := h.Gzip && acceptsGzip(r)
+	etag := contentETag(content, useGzip)
+
+	header := w.Header()
+	header.Set("Content-Type", contentType)
+	header.Set("ETag", etag)
+	if h.Gzip {
+		header.Add("Vary", "Accept-Encoding")
+	}
+
+	ifNoneMatch := r.Header.Get("If-None-Match")
+	if status == http.StatusOK && etagMatches(ifNoneMatch, etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	if useGzip {
+		header.Set("Content-Encoding", "gzip")
+		w.WriteHeader(status)
+		if r.Method == http.MethodHead {
+			return
+		}
+		writer := gzip.NewWriter(w)
+		if _, err := writer.Write([]byte(content)); err != nil {
+			h.Logger.Println(err)
+		}
+		if err := writer.Close(); err != nil {
+			h.Logger.Println(err)
+		}
+		return
+	}
+
+	w.WriteHeader(status)
+	if r.Method == http.MethodHead {
+		return
+	}
+	if _, err := w.Write([]byte(content)); err != nil {
+		h.Logger.Println(err)
+	}
+}
+
+// Create a strong ETag from a hash of the content. Compressed responses use a
+// different ETag from uncompressed ones as they are different representations
+// of the same resource.
+func contentETag(content string, gzipped bool) string {
+	hash := sha256.Sum256([]byte(content))
+	etag := "\"" + hex.EncodeToString(hash[:16])
+	if gzipped {
+		etag += "-gzip"
+	}
+	return etag + "\""
+}
+
+// Check whether an `If-None-Match` header matches the given ETag. As required
+// for `If-None-Match`, weak comparison is used.
+func etagMatches(header string, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// Check whether a request's `Accept-Encoding` header allows gzip.
+func acceptsGzip(r *http.Request) bool {
+	header := r.Header.Get("Accept-Encoding")
+	for _, encoding := range strings.Split(header, ",") {
+		parts := strings.Split(encoding, ";")
+		name := strings.TrimSpace(parts[0])
+		if name != "gzip" && name != "*" {
+			continue
+		}
+		for _, param := range parts[1:] {
+			param = strings.TrimSpace(param)
+			if !strings.HasPrefix(param, "q=") {
+				continue
+			}
+			q, err := strconv.ParseFloat(strings.TrimPrefix(param, "q="), 64)
+			if err == nil && q == 0 {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
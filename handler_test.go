@@ -0,0 +1,181 @@
+package smetana
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newTestHandler() *Handler {
+	smetana := NewSmetanaWithPalettes(Palettes{
+		"light": {"bg": Hex("#fff")},
+		"dark":  {"bg": Hex("#000")},
+	})
+	smetana.Styles.AddBlock("body", CssProps{{"background", PaletteValue("bg")}})
+	handler := NewHandler(&smetana)
+	handler.Logger = log.New(io.Discard, "", 0)
+	handler.Page("/", func(r *http.Request) Node {
+		return Div("Hello ", r.URL.Query().Get("name"))
+	})
+	return handler
+}
+
+func serveTestRequest(handler http.Handler, r *http.Request) *http.Response {
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, r)
+	return recorder.Result()
+}
+
+func readTestBody(t *testing.T, response *http.Response) string {
+	body, err := io.ReadAll(response.Body)
+	assertEqual(t, nil, err)
+	return string(body)
+}
+
+func TestHandlerServesPages(t *testing.T) {
+	handler := newTestHandler()
+	response := serveTestRequest(
+		handler,
+		httptest.NewRequest("GET", "/?name=world", nil),
+	)
+	assertEqual(t, http.StatusOK, response.StatusCode)
+	assertEqual(t, "text/html; charset=utf-8", response.Header.Get("Content-Type"))
+	assertNotEqual(t, "", response.Header.Get("ETag"))
+	assertEqual(t, "<div>Hello world</div>", readTestBody(t, response))
+}
+
+func TestHandlerServesStylesForEachPalette(t *testing.T) {
+	handler := newTestHandler()
+	assertEqual(t, "/styles/dark.css", handler.StylesHref("dark"))
+	response := serveTestRequest(
+		handler,
+		httptest.NewRequest("GET", "/styles/light.css", nil),
+	)
+	assertEqual(t, http.StatusOK, response.StatusCode)
+	assertEqual(t, "text/css; charset=utf-8", response.Header.Get("Content-Type"))
+	assertEqual(t, "body{background:#FFFFFF;}", readTestBody(t, response))
+	response = serveTestRequest(
+		handler,
+		httptest.NewRequest("GET", "/styles/dark.css", nil),
+	)
+	assertEqual(t, "body{background:#000000;}", readTestBody(t, response))
+}
+
+func TestHandlerReturnsNotFound(t *testing.T) {
+	handler := newTestHandler()
+	for _, path := range []string{"/missing", "/styles/missing.css", "/styles/"} {
+		response := serveTestRequest(handler, httptest.NewRequest("GET", path, nil))
+		assertEqual(t, http.StatusNotFound, response.StatusCode)
+	}
+	handler.NotFound = func(r *http.Request) Node {
+		return P("Not found: ", r.URL.Path)
+	}
+	response := serveTestRequest(handler, httptest.NewRequest("GET", "/x", nil))
+	assertEqual(t, http.StatusNotFound, response.StatusCode)
+	assertEqual(t, "<p>Not found: /x</p>", readTestBody(t, response))
+}
+
+func TestHandlerRejectsUnsupportedMethods(t *testing.T) {
+	handler := newTestHandler()
+	response := serveTestRequest(handler, httptest.NewRequest("POST", "/", nil))
+	assertEqual(t, http.StatusMethodNotAllowed, response.StatusCode)
+	assertEqual(t, "GET, HEAD", response.Header.Get("Allow"))
+}
+
+func TestHandlerHeadRequestsHaveNoBody(t *testing.T) {
+	handler := newTestHandler()
+	response := serveTestRequest(handler, httptest.NewRequest("HEAD", "/", nil))
+	assertEqual(t, http.StatusOK, response.StatusCode)
+	assertEqual(t, "", readTestBody(t, response))
+}
+
+func TestHandlerSupportsIfNoneMatch(t *testing.T) {
+	handler := newTestHandler()
+	response := serveTestRequest(
+		handler,
+		httptest.NewRequest("GET", "/styles/light.css", nil),
+	)
+	etag := response.Header.Get("ETag")
+
+	request := httptest.NewRequest("GET", "/styles/light.css", nil)
+	request.Header.Set("If-None-Match", "\"other\", W/"+etag)
+	response = serveTestRequest(handler, request)
+	assertEqual(t, http.StatusNotModified, response.StatusCode)
+	assertEqual(t, "", readTestBody(t, response))
+
+	request = httptest.NewRequest("GET", "/styles/dark.css", nil)
+	request.Header.Set("If-None-Match", etag)
+	response = serveTestRequest(handler, request)
+	assertEqual(t, http.StatusOK, response.StatusCode)
+	assertNotEqual(t, etag, response.Header.Get("ETag"))
+}
+
+func TestHandlerCanGzipResponses(t *testing.T) {
+	handler := newTestHandler()
+	handler.Gzip = true
+
+	request := httptest.NewRequest("GET", "/?name=gzip", nil)
+	request.Header.Set("Accept-Encoding", "deflate, gzip;q=0.8")
+	response := serveTestRequest(handler, request)
+	assertEqual(t, "gzip", response.Header.Get("Content-Encoding"))
+	assertEqual(t, "Accept-Encoding", response.Header.Get("Vary"))
+	body, err := io.ReadAll(response.Body)
+	assertEqual(t, nil, err)
+	reader, err := gzip.NewReader(bytes.NewReader(body))
+	assertEqual(t, nil, err)
+	html, err := io.ReadAll(reader)
+	assertEqual(t, nil, err)
+	assertEqual(t, "<div>Hello gzip</div>", string(html))
+
+	for _, encoding := range []string{"", "deflate", "gzip;q=0"} {
+		request = httptest.NewRequest("GET", "/?name=plain", nil)
+		request.Header.Set("Accept-Encoding", encoding)
+		response = serveTestRequest(handler, request)
+		assertEqual(t, "", response.Header.Get("Content-Encoding"))
+		assertEqual(t, "<div>Hello plain</div>", readTestBody(t, response))
+	}
+}
+
+func TestHandlerGzipHeadRequestsHaveNoBody(t *testing.T) {
+	handler := newTestHandler()
+	handler.Gzip = true
+	request := httptest.NewRequest("HEAD", "/", nil)
+	request.Header.Set("Accept-Encoding", "gzip;level=1")
+	response := serveTestRequest(handler, request)
+	assertEqual(t, http.StatusOK, response.StatusCode)
+	assertEqual(t, "gzip", response.Header.Get("Content-Encoding"))
+	assertEqual(t, "", readTestBody(t, response))
+}
+
+// A [http.ResponseWriter] whose body writes always fail, as if the client
+// had disconnected.
+type failingResponseWriter struct {
+	header http.Header
+}
+
+func (w *failingResponseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *failingResponseWriter) Write(data []byte) (int, error) {
+	return 0, io.ErrClosedPipe
+}
+
+func (w *failingResponseWriter) WriteHeader(status int) {}
+
+func TestHandlerLogsWriteErrors(t *testing.T) {
+	for _, useGzip := range []bool{false, true} {
+		var target bytes.Buffer
+		handler := newTestHandler()
+		handler.Logger = log.New(&target, "", 0)
+		handler.Gzip = useGzip
+		request := httptest.NewRequest("GET", "/", nil)
+		request.Header.Set("Accept-Encoding", "gzip")
+		handler.ServeHTTP(&failingResponseWriter{http.Header{}}, request)
+		assertNotEqual(t, "", target.String())
+	}
+}
@@ -0,0 +1,207 @@
+package smetana
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// A [Node] that renders a `script` tag containing JSON-LD structured data
+// (see https://json-ld.org). The value is marshalled with [json.Marshal],
+// which escapes "<", ">" and "&" so that the data can't close the script
+// tag early. Also see [JsonLd].
+type JsonLdNode struct {
+	Value any
+}
+
+// Convert a [JsonLdNode] to HTML.
+func (node JsonLdNode) ToHtml(builder *Builder) {
+	data, err := json.Marshal(node.Value)
+	if err != nil {
+		builder.Logger.Println(err)
+		return
+	}
+	builder.writeOpeningTag("script", Attrs{"type": "application/ld+json"})
+	builder.Buf.Write(data)
+	builder.writeClosingTag("script")
+}
+
+// Create a [JsonLdNode] for the given value. The value may be any type
+// supported by [json.Marshal], including the typed schema.org helpers such
+// as [JsonLdArticle] and [JsonLdOrganization].
+func JsonLd(value any) JsonLdNode {
+	return JsonLdNode{value}
+}
+
+const schemaOrgContext = "https://schema.org"
+
+// Build a JSON object for a schema.org type, omitting any empty values. Keys
+// are sorted by [json.Marshal] so "@context" and "@type" always come first.
+type jsonLdObject map[string]any
+
+func newJsonLdObject(schemaType string, withContext bool) jsonLdObject {
+	object := jsonLdObject{"@type": schemaType}
+	if withContext {
+		object["@context"] = schemaOrgContext
+	}
+	return object
+}
+
+func (object jsonLdObject) set(key string, value string) {
+	if len(value) > 0 {
+		object[key] = value
+	}
+}
+
+func (object jsonLdObject) setTime(key string, value time.Time) {
+	if !value.IsZero() {
+		object[key] = value.Format(time.RFC3339)
+	}
+}
+
+func (object jsonLdObject) setStrings(key string, values []string) {
+	if len(values) > 0 {
+		object[key] = values
+	}
+}
+
+// A schema.org "Person", most commonly used as the author of a
+// [JsonLdArticle].
+type JsonLdPerson struct {
+	Name string
+	Url  string
+}
+
+// Convert a [JsonLdPerson] to JSON.
+func (person JsonLdPerson) MarshalJSON() ([]byte, error) {
+	object := newJsonLdObject("Person", false)
+	object.set("name", person.Name)
+	object.set("url", person.Url)
+	return json.Marshal(object)
+}
+
+// A single entry in a [JsonLdBreadcrumbList].
+type JsonLdBreadcrumb struct {
+	Name string
+	Url  string
+}
+
+// A schema.org "BreadcrumbList". Positions are assigned to each breadcrumb
+// automatically in the order given.
+type JsonLdBreadcrumbList []JsonLdBreadcrumb
+
+// Convert a [JsonLdBreadcrumbList] to JSON.
+func (list JsonLdBreadcrumbList) MarshalJSON() ([]byte, error) {
+	object := newJsonLdObject("BreadcrumbList", true)
+	items := make([]jsonLdObject, len(list))
+	for i, crumb := range list {
+		item := newJsonLdObject("ListItem", false)
+		item["position"] = i + 1
+		item.set("name", crumb.Name)
+		item.set("item", crumb.Url)
+		items[i] = item
+	}
+	object["itemListElement"] = items
+	return json.Marshal(object)
+}
+
+// A schema.org "Organization".
+type JsonLdOrganization struct {
+	Name   string
+	Url    string
+	Logo   string
+	SameAs []string
+}
+
+// Convert a [JsonLdOrganization] to JSON.
+func (org JsonLdOrganization) MarshalJSON() ([]byte, error) {
+	return json.Marshal(org.toObject(true))
+}
+
+// Build the JSON object for a [JsonLdOrganization]. The "@context" is only
+// included at the top level, and not when nested inside another object.
+func (org JsonLdOrganization) toObject(withContext bool) jsonLdObject {
+	object := newJsonLdObject("Organization", withContext)
+	object.set("name", org.Name)
+	object.set("url", org.Url)
+	object.set("logo", org.Logo)
+	object.setStrings("sameAs", org.SameAs)
+	return object
+}
+
+// A schema.org "Article". Zero values are omitted from the output.
+type JsonLdArticle struct {
+	Headline      string
+	Description   string
+	Url           string
+	Images        []string
+	DatePublished time.Time
+	DateModified  time.Time
+	Authors       []JsonLdPerson
+	Publisher     *JsonLdOrganization
+}
+
+// Convert a [JsonLdArticle] to JSON.
+func (article JsonLdArticle) MarshalJSON() ([]byte, error) {
+	object := newJsonLdObject("Article", true)
+	object.set("headline", article.Headline)
+	object.set("description", article.Description)
+	object.set("url", article.Url)
+	object.setStrings("image", article.Images)
+	object.setTime("datePublished", article.DatePublished)
+	object.setTime("dateModified", article.DateModified)
+	if len(article.Authors) > 0 {
+		object["author"] = article.Authors
+	}
+	if article.Publisher != nil {
+		object["publisher"] = article.Publisher.toObject(false)
+	}
+	return json.Marshal(object)
+}
+
+// A schema.org "Offer", used for the price of a [JsonLdProduct].
+// `Availability` should be a schema.org URL such as
+// "https://schema.org/InStock".
+type JsonLdOffer struct {
+	Price        string
+	Currency     string
+	Availability string
+	Url          string
+}
+
+// Convert a [JsonLdOffer] to JSON.
+func (offer JsonLdOffer) MarshalJSON() ([]byte, error) {
+	object := newJsonLdObject("Offer", false)
+	object.set("price", offer.Price)
+	object.set("priceCurrency", offer.Currency)
+	object.set("availability", offer.Availability)
+	object.set("url", offer.Url)
+	return json.Marshal(object)
+}
+
+// A schema.org "Product". Zero values are omitted from the output.
+type JsonLdProduct struct {
+	Name        string
+	Description string
+	Sku         string
+	Brand       string
+	Images      []string
+	Offers      []JsonLdOffer
+}
+
+// Convert a [JsonLdProduct] to JSON.
+func (product JsonLdProduct) MarshalJSON() ([]byte, error) {
+	object := newJsonLdObject("Product", true)
+	object.set("name", product.Name)
+	object.set("description", product.Description)
+	object.set("sku", product.Sku)
+	if len(product.Brand) > 0 {
+		brand := newJsonLdObject("Brand", false)
+		brand.set("name", product.Brand)
+		object["brand"] = brand
+	}
+	object.setStrings("image", product.Images)
+	if len(product.Offers) > 0 {
+		object["offers"] = product.Offers
+	}
+	return json.Marshal(object)
+}
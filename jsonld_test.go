@@ -0,0 +1,103 @@
+package smetana
+
+import (
+	"log"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRenderJsonLd(t *testing.T) {
+	node := JsonLd(map[string]string{"name": "Foo"})
+	result := RenderHtml(node)
+	expected := "<script type=\"application/ld+json\">{\"name\":\"Foo\"}</script>"
+	assertEqual(t, expected, result)
+}
+
+func TestJsonLdEscapesScriptTags(t *testing.T) {
+	node := JsonLd(map[string]string{"name": "</script><script>alert(1)"})
+	result := RenderHtml(node)
+	expected := "<script type=\"application/ld+json\">" +
+		"{\"name\":\"\\u003c/script\\u003e\\u003cscript\\u003ealert(1)\"}" +
+		"</script>"
+	assertEqual(t, expected, result)
+}
+
+func TestJsonLdLogsMarshalErrors(t *testing.T) {
+	var target strings.Builder
+	logger := log.New(&target, "", 0)
+	result := RenderHtmlOpts(JsonLd(func() {}), false, logger)
+	assertEqual(t, "", result)
+	assertEqual(t, "json: unsupported type: func()", strings.TrimSpace(target.String()))
+}
+
+func TestRenderJsonLdBreadcrumbList(t *testing.T) {
+	node := JsonLd(JsonLdBreadcrumbList{
+		{"Home", "https://example.com"},
+		{"Blog", "https://example.com/blog"},
+	})
+	result := RenderHtml(node)
+	expected := "<script type=\"application/ld+json\">" +
+		"{\"@context\":\"https://schema.org\",\"@type\":\"BreadcrumbList\"," +
+		"\"itemListElement\":[" +
+		"{\"@type\":\"ListItem\",\"item\":\"https://example.com\",\"name\":\"Home\",\"position\":1}," +
+		"{\"@type\":\"ListItem\",\"item\":\"https://example.com/blog\",\"name\":\"Blog\",\"position\":2}" +
+		"]}</script>"
+	assertEqual(t, expected, result)
+}
+
+func TestRenderJsonLdArticle(t *testing.T) {
+	node := JsonLd(JsonLdArticle{
+		Headline:      "Hello",
+		Images:        []string{"https://example.com/a.png"},
+		DatePublished: time.Date(2022, 2, 3, 12, 0, 0, 0, time.UTC),
+		Authors:       []JsonLdPerson{{Name: "Jane"}},
+		Publisher:     &JsonLdOrganization{Name: "Acme"},
+	})
+	result := RenderHtml(node)
+	expected := "<script type=\"application/ld+json\">" +
+		"{\"@context\":\"https://schema.org\",\"@type\":\"Article\"," +
+		"\"author\":[{\"@type\":\"Person\",\"name\":\"Jane\"}]," +
+		"\"datePublished\":\"2022-02-03T12:00:00Z\",\"headline\":\"Hello\"," +
+		"\"image\":[\"https://example.com/a.png\"]," +
+		"\"publisher\":{\"@type\":\"Organization\",\"name\":\"Acme\"}" +
+		"}</script>"
+	assertEqual(t, expected, result)
+}
+
+func TestRenderJsonLdProduct(t *testing.T) {
+	node := JsonLd(JsonLdProduct{
+		Name:  "Widget",
+		Sku:   "W-1",
+		Brand: "Acme",
+		Offers: []JsonLdOffer{{
+			Price:        "9.99",
+			Currency:     "USD",
+			Availability: "https://schema.org/InStock",
+		}},
+	})
+	result := RenderHtml(node)
+	expected := "<script type=\"application/ld+json\">" +
+		"{\"@context\":\"https://schema.org\",\"@type\":\"Product\"," +
+		"\"brand\":{\"@type\":\"Brand\",\"name\":\"Acme\"},\"name\":\"Widget\"," +
+		"\"offers\":[{\"@type\":\"Offer\",\"availability\":\"https://schema.org/InStock\"," +
+		"\"price\":\"9.99\",\"priceCurrency\":\"USD\"}],\"sku\":\"W-1\"" +
+		"}</script>"
+	assertEqual(t, expected, result)
+}
+
+func TestRenderJsonLdOrganization(t *testing.T) {
+	node := JsonLd(JsonLdOrganization{
+		Name:   "Acme",
+		Url:    "https://example.com",
+		Logo:   "https://example.com/logo.png",
+		SameAs: []string{"https://github.com/acme"},
+	})
+	result := RenderHtml(node)
+	expected := "<script type=\"application/ld+json\">" +
+		"{\"@context\":\"https://schema.org\",\"@type\":\"Organization\"," +
+		"\"logo\":\"https://example.com/logo.png\",\"name\":\"Acme\"," +
+		"\"sameAs\":[\"https://github.com/acme\"],\"url\":\"https://example.com\"" +
+		"}</script>"
+	assertEqual(t, expected, result)
+}
@@ -0,0 +1,130 @@
+package smetana
+
+import "encoding/json"
+
+// A valid [WebManifest] "display" mode.
+type ManifestDisplay string
+
+const (
+	// A [WebManifest] with no specified display mode
+	DisplayNone ManifestDisplay = ""
+	// A [WebManifest] with display mode "fullscreen"
+	DisplayFullscreen ManifestDisplay = "fullscreen"
+	// A [WebManifest] with display mode "standalone"
+	DisplayStandalone ManifestDisplay = "standalone"
+	// A [WebManifest] with display mode "minimal-ui"
+	DisplayMinimalUi ManifestDisplay = "minimal-ui"
+	// A [WebManifest] with display mode "browser"
+	DisplayBrowser ManifestDisplay = "browser"
+)
+
+// A single icon in a [WebManifest]. `Sizes` is a space-separated list of
+// dimensions such as "192x192", and `Type` is a MIME type such as
+// "image/png". All fields other than `Src` are optional.
+type ManifestIcon struct {
+	Src     string `json:"src"`
+	Sizes   string `json:"sizes,omitempty"`
+	Type    string `json:"type,omitempty"`
+	Purpose string `json:"purpose,omitempty"`
+}
+
+// [WebManifest] represents a web application manifest according to the
+// specification at https://www.w3.org/TR/appmanifest/
+//
+// `ThemeColor` and `BackgroundColor` may be any type supported by
+// [CssValueToString], but are most commonly a [Color] or a [PaletteValue] so
+// that a different manifest can be rendered for each [Palette]. Nil values are
+// omitted from the output, as are values that can't be resolved (such as a
+// [PaletteValue] that is missing from the [Palette]), which are logged with
+// the [Builder]'s `Logger` instead.
+//
+// Convert to a JSON string with the [ToJson] method.
+type WebManifest struct {
+	Name            string
+	ShortName       string
+	Description     string
+	StartUrl        string
+	Display         ManifestDisplay
+	Icons           []ManifestIcon
+	ThemeColor      any
+	BackgroundColor any
+}
+
+type webManifestJson struct {
+	Name            string         `json:"name,omitempty"`
+	ShortName       string         `json:"short_name,omitempty"`
+	Description     string         `json:"description,omitempty"`
+	StartUrl        string         `json:"start_url,omitempty"`
+	Display         string         `json:"display,omitempty"`
+	Icons           []ManifestIcon `json:"icons,omitempty"`
+	ThemeColor      string         `json:"theme_color,omitempty"`
+	BackgroundColor string         `json:"background_color,omitempty"`
+}
+
+func resolveManifestColor(builder *Builder, palette Palette, value any) string {
+	if value == nil {
+		return ""
+	}
+	str, err := CssValueToString(palette, value)
+	if err != nil {
+		builder.Logger.Println(err)
+		return ""
+	}
+	return str
+}
+
+// Convert a [WebManifest] to a JSON string, resolving any [PaletteValue]
+// colors from the given [Palette].
+func (manifest WebManifest) ToJson(builder *Builder, palette Palette) {
+	// Every field is a string so marshalling can't fail
+	data, _ := json.Marshal(webManifestJson{
+		Name:            manifest.Name,
+		ShortName:       manifest.ShortName,
+		Description:     manifest.Description,
+		StartUrl:        manifest.StartUrl,
+		Display:         string(manifest.Display),
+		Icons:           manifest.Icons,
+		ThemeColor:      resolveManifestColor(builder, palette, manifest.ThemeColor),
+		BackgroundColor: resolveManifestColor(builder, palette, manifest.BackgroundColor),
+	})
+	builder.Buf.Write(data)
+}
+
+// Create the [Node]s to insert into the `head` of a document for a
+// [WebManifest] served at the given `href`. This includes a manifest `link`,
+// a "theme-color" [MetaNode] (if the manifest has a theme color), and an icon
+// `link` for each of the manifest icons. If the theme color can't be resolved
+// then the [MetaNode] is omitted and the error is logged when rendering.
+func ManifestHead(href string, manifest WebManifest, palette Palette) FragmentNode {
+	node := Fragment(LinkHref("manifest", href))
+	if manifest.ThemeColor != nil {
+		node.AssignChildren(Children{
+			manifestThemeColorNode{manifest.ThemeColor, palette},
+		})
+	}
+	for _, icon := range manifest.Icons {
+		link := LinkHref("icon", icon.Src)
+		if len(icon.Sizes) > 0 {
+			link.Attrs["sizes"] = icon.Sizes
+		}
+		if len(icon.Type) > 0 {
+			link.Attrs["type"] = icon.Type
+		}
+		node.AssignChildren(Children{link})
+	}
+	return node
+}
+
+// A "theme-color" [MetaNode] whose color is resolved when rendering, so that
+// errors can be logged with the [Builder]'s `Logger`.
+type manifestThemeColorNode struct {
+	color   any
+	palette Palette
+}
+
+func (node manifestThemeColorNode) ToHtml(builder *Builder) {
+	color := resolveManifestColor(builder, node.palette, node.color)
+	if len(color) > 0 {
+		Meta("theme-color", color).ToHtml(builder)
+	}
+}
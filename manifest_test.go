@@ -0,0 +1,74 @@
+package smetana
+
+import (
+	"log"
+	"strings"
+	"testing"
+)
+
+func createTestManifest() WebManifest {
+	return WebManifest{
+		Name:      "My App",
+		ShortName: "App",
+		StartUrl:  "/",
+		Display:   DisplayStandalone,
+		Icons: []ManifestIcon{
+			{Src: "/icon-192.png", Sizes: "192x192", Type: "image/png"},
+			{Src: "/icon.svg"},
+		},
+		ThemeColor:      PaletteValue("theme"),
+		BackgroundColor: Hex("#fff"),
+	}
+}
+
+func TestCanRenderWebManifest(t *testing.T) {
+	palette := Palette{"theme": Hex("#f00")}
+	result := RenderWebManifest(createTestManifest(), palette)
+	expected := "{\"name\":\"My App\",\"short_name\":\"App\",\"start_url\":\"/\"," +
+		"\"display\":\"standalone\",\"icons\":[" +
+		"{\"src\":\"/icon-192.png\",\"sizes\":\"192x192\",\"type\":\"image/png\"}," +
+		"{\"src\":\"/icon.svg\"}]," +
+		"\"theme_color\":\"#FF0000\",\"background_color\":\"#FFFFFF\"}"
+	assertEqual(t, expected, result)
+}
+
+func TestCanRenderEmptyWebManifest(t *testing.T) {
+	result := RenderWebManifest(WebManifest{}, Palette{})
+	assertEqual(t, "{}", result)
+}
+
+func TestWebManifestLogsMissingPaletteValues(t *testing.T) {
+	var target strings.Builder
+	logger := log.New(&target, "", 0)
+	manifest := WebManifest{ThemeColor: PaletteValue("missing")}
+	result := RenderWebManifestOpts(manifest, Palette{}, logger)
+	assertEqual(t, "{}", result)
+	assertEqual(t, "Missing palette value: missing", strings.TrimSpace(target.String()))
+}
+
+func TestCanRenderManifestHead(t *testing.T) {
+	palette := Palette{"theme": Hex("#f00")}
+	node := ManifestHead("/manifest.json", createTestManifest(), palette)
+	result := RenderHtmlOpts(node, true, nil)
+	expected := "<link href=\"/manifest.json\" rel=\"manifest\">" +
+		"<meta content=\"#FF0000\" name=\"theme-color\">" +
+		"<link href=\"/icon-192.png\" rel=\"icon\" sizes=\"192x192\" type=\"image/png\">" +
+		"<link href=\"/icon.svg\" rel=\"icon\">"
+	assertEqual(t, expected, result)
+}
+
+func TestManifestHeadOmitsMissingThemeColor(t *testing.T) {
+	node := ManifestHead("/manifest.json", WebManifest{}, Palette{})
+	result := RenderHtmlOpts(node, true, nil)
+	assertEqual(t, "<link href=\"/manifest.json\" rel=\"manifest\">", result)
+}
+
+func TestManifestHeadLogsMissingThemeColor(t *testing.T) {
+	var target strings.Builder
+	logger := log.New(&target, "", 0)
+	manifest := WebManifest{ThemeColor: PaletteValue("missing")}
+	node := ManifestHead("/manifest.json", manifest, Palette{})
+	result := RenderHtmlOpts(node, true, logger)
+	assertEqual(t, "<link href=\"/manifest.json\" rel=\"manifest\">", result)
+	assertEqual(t, "Missing palette value: missing", strings.TrimSpace(target.String()))
+}
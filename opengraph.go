@@ -0,0 +1,129 @@
+package smetana
+
+import (
+	"strconv"
+	"time"
+)
+
+// An image in an [OpenGraph] structure. All fields other than `Url` are
+// optional, and zero values are omitted from the output.
+type OpenGraphImage struct {
+	Url    string
+	Type   string
+	Alt    string
+	Width  int
+	Height int
+}
+
+// [OpenGraph] represents Open Graph metadata for a page according to the
+// protocol at https://ogp.me. It implements [Node] and expands into a
+// [PropertyNode] for each non-empty field, so it can be passed directly to
+// [Head].
+//
+// The "article:*" fields are only rendered if `Type` is "article". Zero
+// values are omitted from the output.
+type OpenGraph struct {
+	Title         string
+	Description   string
+	Type          string
+	Url           string
+	SiteName      string
+	Locale        string
+	Images        []OpenGraphImage
+	PublishedTime time.Time
+	ModifiedTime  time.Time
+	Authors       []string
+	Section       string
+	Tags          []string
+}
+
+func appendProperty(node *FragmentNode, property string, content string) {
+	if len(content) > 0 {
+		node.AssignChildren(Children{Property(property, content)})
+	}
+}
+
+func appendPropertyTime(node *FragmentNode, property string, value time.Time) {
+	if !value.IsZero() {
+		appendProperty(node, property, value.Format(time.RFC3339))
+	}
+}
+
+func appendPropertyInt(node *FragmentNode, property string, value int) {
+	if value > 0 {
+		appendProperty(node, property, strconv.Itoa(value))
+	}
+}
+
+// Expand an [OpenGraph] structure into a [FragmentNode] of [PropertyNode]s.
+func (og OpenGraph) Nodes() FragmentNode {
+	node := Fragment()
+	appendProperty(&node, "og:title", og.Title)
+	appendProperty(&node, "og:description", og.Description)
+	appendProperty(&node, "og:type", og.Type)
+	appendProperty(&node, "og:url", og.Url)
+	appendProperty(&node, "og:site_name", og.SiteName)
+	appendProperty(&node, "og:locale", og.Locale)
+	for _, image := range og.Images {
+		appendProperty(&node, "og:image", image.Url)
+		appendProperty(&node, "og:image:type", image.Type)
+		appendPropertyInt(&node, "og:image:width", image.Width)
+		appendPropertyInt(&node, "og:image:height", image.Height)
+		appendProperty(&node, "og:image:alt", image.Alt)
+	}
+	if og.Type == "article" {
+		appendPropertyTime(&node, "article:published_time", og.PublishedTime)
+		appendPropertyTime(&node, "article:modified_time", og.ModifiedTime)
+		for _, author := range og.Authors {
+			appendProperty(&node, "article:author", author)
+		}
+		appendProperty(&node, "article:section", og.Section)
+		for _, tag := range og.Tags {
+			appendProperty(&node, "article:tag", tag)
+		}
+	}
+	return node
+}
+
+// Convert an [OpenGraph] structure to HTML.
+func (og OpenGraph) ToHtml(builder *Builder) {
+	og.Nodes().ToHtml(builder)
+}
+
+// [TwitterCard] represents Twitter/X card metadata for a page. It implements
+// [Node] and expands into a [MetaNode] for each non-empty field, so it can be
+// passed directly to [Head]. `Card` is the card type, such as "summary" or
+// "summary_large_image".
+type TwitterCard struct {
+	Card        string
+	Site        string
+	Creator     string
+	Title       string
+	Description string
+	Image       string
+	ImageAlt    string
+}
+
+func appendMeta(node *FragmentNode, name string, content string) {
+	if len(content) > 0 {
+		node.AssignChildren(Children{Meta(name, content)})
+	}
+}
+
+// Expand a [TwitterCard] structure into a [FragmentNode] of [MetaNode]s.
+func (card TwitterCard) Nodes() FragmentNode {
+	node := Fragment()
+	appendMeta(&node, "twitter:card", card.Card)
+	appendMeta(&node, "twitter:site", card.Site)
+	appendMeta(&node, "twitter:creator", card.Creator)
+	appendMeta(&node, "twitter:title", card.Title)
+	appendMeta(&node, "twitter:description", card.Description)
+	appendMeta(&node, "twitter:image", card.Image)
+	appendMeta(&node, "twitter:image:alt", card.ImageAlt)
+	return node
+}
+
+// Convert a [TwitterCard] structure to HTML.
+func (card TwitterCard) ToHtml(builder *Builder) {
+	card.Nodes().ToHtml(builder)
+}
@@ -0,0 +1,75 @@
+package smetana
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRenderOpenGraph(t *testing.T) {
+	og := OpenGraph{
+		Title: "My Page",
+		Type:  "website",
+		Url:   "https://example.com",
+		Images: []OpenGraphImage{
+			{Url: "https://example.com/a.png", Type: "image/png", Width: 1200, Height: 630, Alt: "A"},
+			{Url: "https://example.com/b.png"},
+		},
+		PublishedTime: time.Date(2022, 2, 3, 12, 0, 0, 0, time.UTC),
+	}
+	result := RenderHtmlOpts(og, true, nil)
+	expected := "<meta content=\"My Page\" property=\"og:title\">" +
+		"<meta content=\"website\" property=\"og:type\">" +
+		"<meta content=\"https://example.com\" property=\"og:url\">" +
+		"<meta content=\"https://example.com/a.png\" property=\"og:image\">" +
+		"<meta content=\"image/png\" property=\"og:image:type\">" +
+		"<meta content=\"1200\" property=\"og:image:width\">" +
+		"<meta content=\"630\" property=\"og:image:height\">" +
+		"<meta content=\"A\" property=\"og:image:alt\">" +
+		"<meta content=\"https://example.com/b.png\" property=\"og:image\">"
+	assertEqual(t, expected, result)
+}
+
+func TestRenderOpenGraphArticle(t *testing.T) {
+	og := OpenGraph{
+		Type:          "article",
+		PublishedTime: time.Date(2022, 2, 3, 12, 0, 0, 0, time.UTC),
+		ModifiedTime:  time.Date(2022, 2, 4, 12, 0, 0, 0, time.UTC),
+		Authors:       []string{"https://example.com/me"},
+		Section:       "Tech",
+		Tags:          []string{"go", "html"},
+	}
+	result := RenderHtmlOpts(og, true, nil)
+	expected := "<meta content=\"article\" property=\"og:type\">" +
+		"<meta content=\"2022-02-03T12:00:00Z\" property=\"article:published_time\">" +
+		"<meta content=\"2022-02-04T12:00:00Z\" property=\"article:modified_time\">" +
+		"<meta content=\"https://example.com/me\" property=\"article:author\">" +
+		"<meta content=\"Tech\" property=\"article:section\">" +
+		"<meta content=\"go\" property=\"article:tag\">" +
+		"<meta content=\"html\" property=\"article:tag\">"
+	assertEqual(t, expected, result)
+}
+
+func TestRenderTwitterCard(t *testing.T) {
+	card := TwitterCard{
+		Card:     "summary_large_image",
+		Site:     "@example",
+		Title:    "My Page",
+		Image:    "https://example.com/a.png",
+		ImageAlt: "A",
+	}
+	result := RenderHtmlOpts(card, true, nil)
+	expected := "<meta content=\"summary_large_image\" name=\"twitter:card\">" +
+		"<meta content=\"@example\" name=\"twitter:site\">" +
+		"<meta content=\"My Page\" name=\"twitter:title\">" +
+		"<meta content=\"https://example.com/a.png\" name=\"twitter:image\">" +
+		"<meta content=\"A\" name=\"twitter:image:alt\">"
+	assertEqual(t, expected, result)
+}
+
+func TestOpenGraphCanBePassedToHead(t *testing.T) {
+	node := Head(OpenGraph{Title: "Foo"}, TwitterCard{Card: "summary"})
+	result := RenderHtmlOpts(node, true, nil)
+	expected := "<head><meta content=\"Foo\" property=\"og:title\">" +
+		"<meta content=\"summary\" name=\"twitter:card\"></head>"
+	assertEqual(t, expected, result)
+}
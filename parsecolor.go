@@ -0,0 +1,233 @@
+package smetana
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Parse a CSS color string into a [Color]. The following formats are
+// supported:
+//   - Hex strings with 3, 4, 6 or 8 digits (ie; "#FFF" or "#FFFFFF80")
+//   - `rgb()` and `rgba()` in legacy comma-separated or modern
+//     space-separated syntax, with channels as numbers or percentages
+//   - `hsl()` and `hsla()` in legacy comma-separated or modern
+//     space-separated syntax, with the hue in "deg", "rad", "grad" or "turn"
+//   - All 148 CSS named colors, plus "transparent"
+//
+// The result is an [RGB] or [HSL] for opaque colors, or an [RGBA] or [HSLA]
+// if an alpha channel was provided. Parsing is case-insensitive.
+func ParseColor(s string) (Color, error) {
+	value := strings.ToLower(strings.TrimSpace(s))
+
+	if strings.HasPrefix(value, "#") {
+		color, err := parseHexColor(value[1:])
+		if err != nil {
+			return nil, fmt.Errorf("Invalid color: %s", s)
+		}
+		return color, nil
+	}
+
+	if value == "transparent" {
+		return RGBA{0, 0, 0, 0}, nil
+	}
+
+	if color, ok := namedColors[value]; ok {
+		return color, nil
+	}
+
+	open := strings.IndexByte(value, '(')
+	if open < 0 || !strings.HasSuffix(value, ")") {
+		return nil, fmt.Errorf("Invalid color: %s", s)
+	}
+
+	name := strings.TrimSpace(value[:open])
+	args, alpha, err := splitColorArgs(value[open+1 : len(value)-1])
+	if err != nil {
+		return nil, fmt.Errorf("Invalid color: %s", s)
+	}
+
+	var color Color
+	switch name {
+	case "rgb", "rgba":
+		color, err = parseRgbArgs(args, alpha)
+	case "hsl", "hsla":
+		color, err = parseHslArgs(args, alpha)
+	default:
+		err = fmt.Errorf("Unknown color function: %s", name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Invalid color: %s", s)
+	}
+	return color, nil
+}
+
+func parseHexColor(digits string) (Color, error) {
+	if len(digits) == 3 || len(digits) == 4 {
+		long := make([]byte, 0, len(digits)*2)
+		for i := 0; i < len(digits); i++ {
+			long = append(long, digits[i], digits[i])
+		}
+		digits = string(long)
+	}
+	if len(digits) != 6 && len(digits) != 8 {
+		return nil, fmt.Errorf("Invalid hex color length: %d", len(digits))
+	}
+	value, err := strconv.ParseUint(digits, 16, 32)
+	if err != nil {
+		return nil, err
+	}
+	if len(digits) == 6 {
+		return RGB{
+			uint8((value >> 16) & 0xff),
+			uint8((value >> 8) & 0xff),
+			uint8(value & 0xff),
+		}, nil
+	}
+	return RGBA{
+		uint8((value >> 24) & 0xff),
+		uint8((value >> 16) & 0xff),
+		uint8((value >> 8) & 0xff),
+		uint8(value & 0xff),
+	}, nil
+}
+
+// Split the arguments of a CSS color function into its three main components
+// and an optional alpha component (which is the empty string if absent).
+// Both the legacy "a, b, c, d" syntax and the modern "a b c / d" syntax are
+// supported.
+func splitColorArgs(body string) ([]string, string, error) {
+	var args []string
+	var alpha string
+	if strings.Contains(body, ",") {
+		args = strings.Split(body, ",")
+		for i := range args {
+			args[i] = strings.TrimSpace(args[i])
+			if len(args[i]) < 1 {
+				return nil, "", fmt.Errorf("Empty color component")
+			}
+		}
+		if len(args) == 4 {
+			alpha = args[3]
+			args = args[:3]
+		}
+	} else {
+		main, rest, hasAlpha := strings.Cut(body, "/")
+		args = strings.Fields(main)
+		if hasAlpha {
+			alpha = strings.TrimSpace(rest)
+			if len(alpha) < 1 {
+				return nil, "", fmt.Errorf("Missing alpha value")
+			}
+		}
+	}
+	if len(args) != 3 {
+		return nil, "", fmt.Errorf("Expected 3 color components, got %d", len(args))
+	}
+	return args, alpha, nil
+}
+
+// Parse a number or percentage. Percentages are multiplied by
+// `percentScale / 100`.
+func parseColorNumber(value string, percentScale float64) (float64, error) {
+	if strings.HasSuffix(value, "%") {
+		result, err := parseFiniteFloat(strings.TrimSuffix(value, "%"))
+		return result * percentScale / 100, err
+	}
+	return parseFiniteFloat(value)
+}
+
+// Parse a float, rejecting the "NaN" and "Inf" values that
+// [strconv.ParseFloat] accepts but which aren't valid in CSS.
+func parseFiniteFloat(value string) (float64, error) {
+	result, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, err
+	}
+	if math.IsNaN(result) || math.IsInf(result, 0) {
+		return 0, fmt.Errorf("Non-finite color component: %s", value)
+	}
+	return result, nil
+}
+
+// Parse an alpha value into a float between 0.0 and 1.0 inclusive.
+func parseColorAlpha(value string) (float64, error) {
+	alpha, err := parseColorNumber(value, 1)
+	return clamp(alpha, 0.0, 1.0), err
+}
+
+func parseRgbArgs(args []string, alpha string) (Color, error) {
+	var channels [3]uint8
+	for i, arg := range args {
+		value, err := parseColorNumber(arg, 255)
+		if err != nil {
+			return nil, err
+		}
+		channels[i] = uint8(math.Round(clamp(value, 0.0, 255.0)))
+	}
+	if len(alpha) < 1 {
+		return RGB{channels[0], channels[1], channels[2]}, nil
+	}
+	a, err := parseColorAlpha(alpha)
+	if err != nil {
+		return nil, err
+	}
+	return RGBA{channels[0], channels[1], channels[2], floatToChannel(a)}, nil
+}
+
+// Parse a CSS hue into degrees between 0 and 360.
+func parseHue(value string) (float32, error) {
+	units := []struct {
+		suffix string
+		scale  float64
+	}{
+		{"deg", 1},
+		{"grad", 0.9},
+		{"rad", 180 / math.Pi},
+		{"turn", 360},
+	}
+	scale := 1.0
+	for _, unit := range units {
+		if strings.HasSuffix(value, unit.suffix) {
+			value = strings.TrimSuffix(value, unit.suffix)
+			scale = unit.scale
+			break
+		}
+	}
+	hue, err := parseFiniteFloat(value)
+	if err != nil {
+		return 0, err
+	}
+	hue = math.Mod(hue*scale, 360)
+	if hue < 0 {
+		hue += 360
+	}
+	return float32(hue), nil
+}
+
+func parseHslArgs(args []string, alpha string) (Color, error) {
+	h, err := parseHue(args[0])
+	if err != nil {
+		return nil, err
+	}
+	// Saturation and lightness may be given with or without a "%" suffix
+	s, err := parseFiniteFloat(strings.TrimSuffix(args[1], "%"))
+	if err != nil {
+		return nil, err
+	}
+	l, err := parseFiniteFloat(strings.TrimSuffix(args[2], "%"))
+	if err != nil {
+		return nil, err
+	}
+	S := float32(clamp(s/100, 0.0, 1.0))
+	L := float32(clamp(l/100, 0.0, 1.0))
+	if len(alpha) < 1 {
+		return HSL{h, S, L}, nil
+	}
+	a, err := parseColorAlpha(alpha)
+	if err != nil {
+		return nil, err
+	}
+	return HSLA{h, S, L, float32(a)}, nil
+}
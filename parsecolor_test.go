@@ -0,0 +1,99 @@
+package smetana
+
+import (
+	"errors"
+	"testing"
+)
+
+type ParseColorTestCase struct {
+	input    string
+	expected Color
+}
+
+func TestParseValidColors(t *testing.T) {
+	tests := []ParseColorTestCase{
+		{"#f00", RGB{255, 0, 0}},
+		{"#F008", RGBA{255, 0, 0, 136}},
+		{"#00ff00", RGB{0, 255, 0}},
+		{"#0000FF80", RGBA{0, 0, 255, 128}},
+		{"  #888  ", RGB{136, 136, 136}},
+		{"red", RGB{255, 0, 0}},
+		{"RebeccaPurple", RGB{102, 51, 153}},
+		{"transparent", RGBA{0, 0, 0, 0}},
+		{"rgb(255, 0, 100)", RGB{255, 0, 100}},
+		{"rgba(255, 0, 100, 0.5)", RGBA{255, 0, 100, 128}},
+		{"rgba(255, 0, 100, 50%)", RGBA{255, 0, 100, 128}},
+		{"rgb(100%, 0%, 50%)", RGB{255, 0, 128}},
+		{"rgb(255 0 100)", RGB{255, 0, 100}},
+		{"rgb(255 0 100 / 0.25)", RGBA{255, 0, 100, 64}},
+		{"rgba(255 0 100 / 100%)", RGBA{255, 0, 100, 255}},
+		{"rgb(300, -5, 12.6)", RGB{255, 0, 13}},
+		{"hsl(120, 50%, 25%)", HSL{120, 0.5, 0.25}},
+		{"hsla(120, 50%, 25%, 0.5)", HSLA{120, 0.5, 0.25, 0.5}},
+		{"hsl(120 50% 25%)", HSL{120, 0.5, 0.25}},
+		{"hsl(120deg 50 25 / 20%)", HSLA{120, 0.5, 0.25, 0.2}},
+		{"hsl(0.5turn 100% 50%)", HSL{180, 1, 0.5}},
+		{"hsl(200grad 100% 50%)", HSL{180, 1, 0.5}},
+		{"hsl(3.14159265rad 100% 50%)", HSL{180, 1, 0.5}},
+		{"hsl(-90 100% 50%)", HSL{270, 1, 0.5}},
+		{"hsl(720 100% 50%)", HSL{0, 1, 0.5}},
+	}
+	for _, test := range tests {
+		result, err := ParseColor(test.input)
+		assertEqual(t, nil, err)
+		assertEqual(t, test.expected, result)
+	}
+}
+
+func TestParseAllNamedColors(t *testing.T) {
+	assertEqual(t, 148, len(namedColors))
+	for name, expected := range namedColors {
+		result, err := ParseColor(name)
+		assertEqual(t, nil, err)
+		assertEqual(t, Color(expected), result)
+	}
+}
+
+func TestParseInvalidColors(t *testing.T) {
+	tests := []string{
+		"",
+		"#",
+		"#12",
+		"#12345",
+		"#1234567",
+		"#xyz",
+		"#gggggg",
+		"notacolor",
+		"rgb(1, 2)",
+		"rgb(1, 2, 3, 4, 5)",
+		"rgb(1 2 3 /)",
+		"rgb(255,0,0,)",
+		"rgba(1,2,3, )",
+		"rgb(,1,2)",
+		"rgb(1,,2)",
+		"hsl(1, 2%, 3%,)",
+		"hsla(1, , 3%, 0.5)",
+		"rgb(a, b, c)",
+		"rgb(1, 2, 3, x)",
+		"rgb(1, 2, 3",
+		"hsl(x, 50%, 50%)",
+		"hsl(120, x, 50%)",
+		"hsl(120, 50%, x)",
+		"hsl(120, 50%, 50%, x)",
+		"rgb(nan, 0, 0)",
+		"rgb(0 inf 0)",
+		"rgb(0, 0, -infinity%)",
+		"rgba(0, 0, 0, nan)",
+		"hsl(inf, 50%, 50%)",
+		"hsl(infinity, 50%, 50%)",
+		"hsl(nandeg, 50%, 50%)",
+		"hsl(120, nan%, 50%)",
+		"hsl(120, 50%, inf%)",
+		"lab(50 20 30)",
+	}
+	for _, test := range tests {
+		result, err := ParseColor(test)
+		assertEqual(t, nil, result)
+		assertEqual(t, errors.New("Invalid color: "+test), err)
+	}
+}
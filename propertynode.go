@@ -0,0 +1,23 @@
+package smetana
+
+// A "meta" [Node] in an HTML document to associate some "content" value to a
+// particular "property", as used by the Open Graph protocol (see [MetaNode]
+// for a more generic node using "name" instead of "property").
+type PropertyNode struct {
+	Property string
+	Content  string
+}
+
+// Convert a [PropertyNode] to HTML
+func (node PropertyNode) ToHtml(builder *Builder) {
+	// `meta` is a void tag so we only need the opening tag
+	builder.writeOpeningTag("meta", Attrs{
+		"property": node.Property,
+		"content":  node.Content,
+	})
+}
+
+// Create a generic [PropertyNode] with the given property and content
+func Property(property string, content string) PropertyNode {
+	return PropertyNode{property, content}
+}
@@ -0,0 +1,9 @@
+package smetana
+
+import "testing"
+
+func TestRenderProperty(t *testing.T) {
+	node := Property("og:title", "bar")
+	result := RenderHtmlOpts(node, true, nil)
+	assertEqual(t, "<meta content=\"bar\" property=\"og:title\">", result)
+}
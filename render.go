@@ -1,9 +1,9 @@
 package smetana
 
 import (
+	"context"
 	"log"
 	"os"
-	"strings"
 )
 
 // Render a [Node] to an HTML string with the default settings.
@@ -24,11 +24,50 @@ func RenderHtmlOpts(
 	if logger == nil {
 		logger = log.New(os.Stderr, "", 0)
 	}
-	builder := Builder{strings.Builder{}, deterministicAttrs, logger}
+	builder := Builder{DeterministicAttributes: deterministicAttrs, Logger: logger}
 	node.ToHtml(&builder)
 	return builder.Buf.String()
 }
 
+// Render a [Node] to an HTML string, computing any [AsyncNode]s concurrently
+// with the given context. If any [AsyncNode] fails or the context is
+// cancelled then the first error in document order is returned along with
+// the HTML, where fallbacks are used for the nodes that failed.
+// See [RenderHtmlContextOpts] for more fine-grained control.
+func RenderHtmlContext(ctx context.Context, node Node) (string, error) {
+	return RenderHtmlContextOpts(ctx, node, 0, false, nil)
+}
+
+// Render a [Node] to an HTML string, computing any [AsyncNode]s concurrently
+// with the given context and specifying particular settings for the internal
+// [Builder]. At most `concurrency` [AsyncNode] functions are run at the same
+// time, or there is no limit if `concurrency` is zero.
+// See the [Builder] struct for the available configuration values.
+// See [RenderHtmlContext] for a simpler interface with default values.
+func RenderHtmlContextOpts(
+	ctx context.Context,
+	node Node,
+	concurrency int,
+	deterministicAttrs bool,
+	logger *log.Logger,
+) (string, error) {
+	if logger == nil {
+		logger = log.New(os.Stderr, "", 0)
+	}
+	renderer := &asyncRenderer{ctx: ctx}
+	if concurrency > 0 {
+		renderer.slots = make(chan struct{}, concurrency)
+	}
+	builder := Builder{
+		DeterministicAttributes: deterministicAttrs,
+		Logger:                  logger,
+		ctx:                     ctx,
+		async:                   &asyncState{renderer: renderer},
+	}
+	node.ToHtml(&builder)
+	return builder.collectAsync()
+}
+
 // Render a [StyleSheet] into a CSS string with the default settings.
 // See [RenderCssOpts] for more fine-grained control.
 func RenderCss(styles StyleSheet, palette Palette) string {
@@ -47,7 +86,7 @@ func RenderCssOpts(
 	if logger == nil {
 		logger = log.New(os.Stderr, "", 0)
 	}
-	builder := Builder{strings.Builder{}, false, logger}
+	builder := Builder{Logger: logger}
 	styles.ToCss(&builder, palette)
 	return builder.Buf.String()
 }
@@ -66,7 +105,97 @@ func RenderSitemapOpts(sitemap Sitemap, logger *log.Logger) string {
 	if logger == nil {
 		logger = log.New(os.Stderr, "", 0)
 	}
-	builder := Builder{strings.Builder{}, false, logger}
+	builder := Builder{Logger: logger}
 	sitemap.ToXml(&builder)
 	return builder.Buf.String()
 }
+
+// Render a [RobotsTxt] into a string with the default settings.
+// See [RenderRobotsTxtOpts] for more fine-grained control.
+func RenderRobotsTxt(robots RobotsTxt) string {
+	return RenderRobotsTxtOpts(robots, nil)
+}
+
+// Render a [RobotsTxt] into a string specifying particular settings for the
+// internal [Builder].
+// See the [Builder] struct for the available configuration values.
+// See [RenderRobotsTxt] for a simpler interface with default values.
+func RenderRobotsTxtOpts(robots RobotsTxt, logger *log.Logger) string {
+	if logger == nil {
+		logger = log.New(os.Stderr, "", 0)
+	}
+	builder := Builder{Logger: logger}
+	robots.ToText(&builder)
+	return builder.Buf.String()
+}
+
+// Render a [Feed] into an RSS 2.0 XML string with the default settings.
+// See [RenderRssOpts] for more fine-grained control.
+func RenderRss(feed Feed) string {
+	return RenderRssOpts(feed, false, nil)
+}
+
+// Render a [Feed] into an RSS 2.0 XML string specifying particular settings
+// for the internal [Builder]. These settings are also used when rendering the
+// content of each [FeedItem].
+// See the [Builder] struct for the available configuration values.
+// See [RenderRss] for a simpler interface with default values.
+func RenderRssOpts(
+	feed Feed,
+	deterministicAttrs bool,
+	logger *log.Logger,
+) string {
+	if logger == nil {
+		logger = log.New(os.Stderr, "", 0)
+	}
+	builder := Builder{DeterministicAttributes: deterministicAttrs, Logger: logger}
+	feed.ToRss(&builder)
+	return builder.Buf.String()
+}
+
+// Render a [Feed] into an Atom 1.0 XML string with the default settings.
+// See [RenderAtomOpts] for more fine-grained control.
+func RenderAtom(feed Feed) string {
+	return RenderAtomOpts(feed, false, nil)
+}
+
+// Render a [Feed] into an Atom 1.0 XML string specifying particular settings
+// for the internal [Builder]. These settings are also used when rendering the
+// content of each [FeedItem].
+// See the [Builder] struct for the available configuration values.
+// See [RenderAtom] for a simpler interface with default values.
+func RenderAtomOpts(
+	feed Feed,
+	deterministicAttrs bool,
+	logger *log.Logger,
+) string {
+	if logger == nil {
+		logger = log.New(os.Stderr, "", 0)
+	}
+	builder := Builder{DeterministicAttributes: deterministicAttrs, Logger: logger}
+	feed.ToAtom(&builder)
+	return builder.Buf.String()
+}
+
+// Render a [WebManifest] into a JSON string with the default settings.
+// See [RenderWebManifestOpts] for more fine-grained control.
+func RenderWebManifest(manifest WebManifest, palette Palette) string {
+	return RenderWebManifestOpts(manifest, palette, nil)
+}
+
+// Render a [WebManifest] into a JSON string specifying particular settings
+// for the internal [Builder].
+// See the [Builder] struct for the available configuration values.
+// See [RenderWebManifest] for a simpler interface with default values.
+func RenderWebManifestOpts(
+	manifest WebManifest,
+	palette Palette,
+	logger *log.Logger,
+) string {
+	if logger == nil {
+		logger = log.New(os.Stderr, "", 0)
+	}
+	builder := Builder{Logger: logger}
+	manifest.ToJson(&builder, palette)
+	return builder.Buf.String()
+}
@@ -0,0 +1,96 @@
+package smetana
+
+import "strconv"
+
+// A single "Allow" or "Disallow" rule in a [RobotsGroup].
+type RobotsRule struct {
+	Allow bool
+	Path  string
+}
+
+// Create a [RobotsRule] allowing crawlers to access the given path.
+func RobotsAllow(path string) RobotsRule {
+	return RobotsRule{true, path}
+}
+
+// Create a [RobotsRule] disallowing crawlers from accessing the given path.
+func RobotsDisallow(path string) RobotsRule {
+	return RobotsRule{false, path}
+}
+
+// A group of rules in a [RobotsTxt] file that apply to one or more user
+// agents. Rules are written in the order given. A `CrawlDelay` of 0 is
+// omitted from the output.
+type RobotsGroup struct {
+	UserAgents []string
+	Rules      []RobotsRule
+	CrawlDelay float64
+}
+
+// Create a [RobotsGroup] for the given user agent with the given rules.
+func NewRobotsGroup(userAgent string, rules ...RobotsRule) RobotsGroup {
+	return RobotsGroup{[]string{userAgent}, rules, 0}
+}
+
+// [RobotsTxt] represents a robots.txt file according to the specification at
+// https://www.rfc-editor.org/rfc/rfc9309.html
+// Each entry in `Sitemaps` should be the absolute URL of a [Sitemap] or
+// sitemap index file.
+// Convert to a string with the [ToText] method.
+type RobotsTxt struct {
+	Groups   []RobotsGroup
+	Sitemaps []string
+}
+
+// Create a new [RobotsTxt] with the given groups.
+func NewRobotsTxt(groups ...RobotsGroup) RobotsTxt {
+	return RobotsTxt{groups, []string{}}
+}
+
+// Add a group of rules to a [RobotsTxt].
+func (robots *RobotsTxt) AddGroup(group RobotsGroup) {
+	robots.Groups = append(robots.Groups, group)
+}
+
+// Add the absolute URL of a [Sitemap] or sitemap index to a [RobotsTxt].
+func (robots *RobotsTxt) AddSitemap(url string) {
+	robots.Sitemaps = append(robots.Sitemaps, url)
+}
+
+func (builder *Builder) writeRobotsLine(key string, value string) {
+	builder.Buf.WriteString(key)
+	builder.Buf.WriteString(": ")
+	builder.Buf.WriteString(value)
+	builder.Buf.WriteByte('\n')
+}
+
+// Convert a [RobotsTxt] to a string.
+func (robots RobotsTxt) ToText(builder *Builder) {
+	for i, group := range robots.Groups {
+		if i > 0 {
+			builder.Buf.WriteByte('\n')
+		}
+		for _, userAgent := range group.UserAgents {
+			builder.writeRobotsLine("User-agent", userAgent)
+		}
+		for _, rule := range group.Rules {
+			if rule.Allow {
+				builder.writeRobotsLine("Allow", rule.Path)
+			} else {
+				builder.writeRobotsLine("Disallow", rule.Path)
+			}
+		}
+		if group.CrawlDelay > 0 {
+			delay := strconv.FormatFloat(group.CrawlDelay, 'f', -1, 64)
+			builder.writeRobotsLine("Crawl-delay", delay)
+		}
+	}
+	if len(robots.Sitemaps) > 0 {
+		if len(robots.Groups) > 0 {
+			builder.Buf.WriteByte('\n')
+		}
+		for _, url := range robots.Sitemaps {
+			builder.writeRobotsLine("Sitemap", url)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package smetana
+
+import "testing"
+
+func TestCreateRobotsRules(t *testing.T) {
+	assertEqual(t, RobotsRule{true, "/foo"}, RobotsAllow("/foo"))
+	assertEqual(t, RobotsRule{false, "/bar"}, RobotsDisallow("/bar"))
+}
+
+func TestCreateRobotsGroup(t *testing.T) {
+	group := NewRobotsGroup("*", RobotsDisallow("/admin"))
+	assertEqual(t, []string{"*"}, group.UserAgents)
+	assertEqual(t, []RobotsRule{{false, "/admin"}}, group.Rules)
+	assertEqual(t, 0.0, group.CrawlDelay)
+}
+
+func TestCanAddToRobotsTxt(t *testing.T) {
+	robots := NewRobotsTxt()
+	robots.AddGroup(NewRobotsGroup("*"))
+	robots.AddSitemap("https://example.com/sitemap.xml")
+	assertEqual(t, 1, len(robots.Groups))
+	assertEqual(t, []string{"https://example.com/sitemap.xml"}, robots.Sitemaps)
+}
+
+func TestCanRenderRobotsTxt(t *testing.T) {
+	robots := NewRobotsTxt(
+		NewRobotsGroup("*", RobotsDisallow("/admin"), RobotsAllow("/admin/public")),
+		RobotsGroup{
+			UserAgents: []string{"Googlebot", "Bingbot"},
+			Rules:      []RobotsRule{RobotsDisallow("/private")},
+			CrawlDelay: 2.5,
+		},
+	)
+	robots.AddSitemap("https://example.com/sitemap.xml")
+	robots.AddSitemap("https://example.com/sitemap-index.xml")
+	expected := "User-agent: *\n" +
+		"Disallow: /admin\n" +
+		"Allow: /admin/public\n" +
+		"\n" +
+		"User-agent: Googlebot\n" +
+		"User-agent: Bingbot\n" +
+		"Disallow: /private\n" +
+		"Crawl-delay: 2.5\n" +
+		"\n" +
+		"Sitemap: https://example.com/sitemap.xml\n" +
+		"Sitemap: https://example.com/sitemap-index.xml\n"
+	assertEqual(t, expected, RenderRobotsTxt(robots))
+}
+
+func TestCanRenderRobotsTxtWithOnlySitemaps(t *testing.T) {
+	robots := NewRobotsTxt()
+	robots.AddSitemap("https://example.com/sitemap.xml")
+	result := RenderRobotsTxt(robots)
+	assertEqual(t, "Sitemap: https://example.com/sitemap.xml\n", result)
+}
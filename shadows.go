@@ -0,0 +1,112 @@
+package smetana
+
+// A single shadow implementing [CssValue], for use with the CSS `box-shadow`
+// and `text-shadow` properties. The offsets, blur radius and spread radius may
+// be any type supported by [CssValueToString], and the color is usually a
+// [Color] or a [PaletteValue]. The blur, spread and color are optional and are
+// omitted when nil. Note that `text-shadow` does not support `Spread` or
+// `Inset`. Shadows are usually created with [Shadow]:
+//
+//	Shadow(0, PX(2), PX(4), PaletteValue("shadow")).WithSpread(PX(1))
+//
+// will render to `0px 2px 4px 1px #00000033` if "shadow" is `#00000033` in
+// the current palette.
+type CssShadow struct {
+	Inset  bool
+	X      any
+	Y      any
+	Blur   any
+	Spread any
+	Color  any
+}
+
+// Create a [CssShadow] with the given offsets, blur radius and color.
+func Shadow(x any, y any, blur any, color any) CssShadow {
+	return CssShadow{false, x, y, blur, nil, color}
+}
+
+// Set the spread radius of a [CssShadow].
+func (shadow CssShadow) WithSpread(spread any) CssShadow {
+	shadow.Spread = spread
+	return shadow
+}
+
+// Convert a [CssShadow] into an inset shadow.
+func (shadow CssShadow) AsInset() CssShadow {
+	shadow.Inset = true
+	return shadow
+}
+
+// Convert a [CssShadow] into a CSS string.
+func (shadow CssShadow) ToCssValue(palette Palette) (string, error) {
+	values := []any{}
+	if shadow.Inset {
+		values = append(values, "inset")
+	}
+	values = append(values, shadow.X, shadow.Y)
+	if shadow.Blur != nil || shadow.Spread != nil {
+		blur := shadow.Blur
+		if blur == nil {
+			blur = "0"
+		}
+		values = append(values, blur)
+	}
+	if shadow.Spread != nil {
+		values = append(values, shadow.Spread)
+	}
+	if shadow.Color != nil {
+		values = append(values, shadow.Color)
+	}
+	return joinCssValues(palette, values, " ")
+}
+
+// Get all of the [PaletteValue]s referenced by a [CssShadow].
+func (shadow CssShadow) PaletteValues() []PaletteValue {
+	values := []PaletteValue{}
+	for _, value := range []any{
+		shadow.X,
+		shadow.Y,
+		shadow.Blur,
+		shadow.Spread,
+		shadow.Color,
+	} {
+		values = append(values, cssValuePaletteValues(value)...)
+	}
+	return values
+}
+
+// A list of [CssShadow]s implementing [CssValue]. Multiple shadows are
+// layered with the first shadow on top. For example,
+//
+//	CssProps{{"box-shadow", Shadows(
+//		Shadow(0, PX(1), PX(2), PaletteValue("shadow")),
+//		Shadow(0, 0, 0, PaletteValue("focus")).WithSpread(PX(3)),
+//	)}}
+type CssShadows []CssShadow
+
+// Create a [CssShadows] list.
+func Shadows(shadows ...CssShadow) CssShadows {
+	return shadows
+}
+
+// Convert a [CssShadows] list into a CSS string. An empty list is rendered
+// as "none".
+func (shadows CssShadows) ToCssValue(palette Palette) (string, error) {
+	if len(shadows) == 0 {
+		return "none", nil
+	}
+	values := make([]any, len(shadows))
+	for i, shadow := range shadows {
+		values[i] = shadow
+	}
+	return joinCssValues(palette, values, ", ")
+}
+
+// Get all of the [PaletteValue]s referenced by a [CssShadows] list.
+func (shadows CssShadows) PaletteValues() []PaletteValue {
+	values := []PaletteValue{}
+	for _, shadow := range shadows {
+		values = append(values, shadow.PaletteValues()...)
+	}
+	return values
+}
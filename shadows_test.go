@@ -0,0 +1,55 @@
+package smetana
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRenderShadows(t *testing.T) {
+	palette := Palette{"shadow": Rgba(0, 0, 0, 51), "focus": Hex("#3366CC")}
+	tests := map[string]any{
+		"1px 1px":                   CssShadow{X: PX(1), Y: PX(1)},
+		"0px 2px 4px #FF0000":       Shadow(0, PX(2), PX(4), Hex("#f00")),
+		"0px 2px 4px 1px #3366CC":   Shadow(0, PX(2), PX(4), PaletteValue("focus")).WithSpread(PX(1)),
+		"0px 0px 0 3px #3366CC":     Shadow(0, 0, nil, PaletteValue("focus")).WithSpread(PX(3)),
+		"inset 0px 1px 2px #3366CC": Shadow(0, PX(1), PX(2), PaletteValue("focus")).AsInset(),
+		"none":                      Shadows(),
+		"0px 1px 2px red, 0px 0px 0px 3px blue": Shadows(
+			Shadow(0, PX(1), PX(2), "red"),
+			Shadow(0, 0, 0, "blue").WithSpread(PX(3)),
+		),
+	}
+	for expected, value := range tests {
+		result, err := CssValueToString(palette, value)
+		assertEqual(t, nil, err)
+		assertEqual(t, expected, result)
+	}
+}
+
+func TestShadowsResolvePaletteValuesPerPalette(t *testing.T) {
+	smetana := NewSmetanaWithPalettes(Palettes{
+		"light": {"shadow": Hex("#ccc")},
+		"dark":  {"shadow": Hex("#111")},
+	})
+	smetana.Styles.AddBlock(".card", CssProps{
+		{"box-shadow", Shadows(Shadow(0, PX(1), PX(3), PaletteValue("shadow")))},
+	})
+	css := smetana.RenderStyles()
+	assertEqual(t, ".card{box-shadow:0px 1px 3px #CCCCCC;}", css["light"])
+	assertEqual(t, ".card{box-shadow:0px 1px 3px #111111;}", css["dark"])
+}
+
+func TestShadowsReportMissingPaletteValues(t *testing.T) {
+	value := Shadows(Shadow(0, 0, PX(1), "red"), Shadow(0, 0, PX(1), PaletteValue("a")))
+	result, err := CssValueToString(Palette{}, value)
+	assertEqual(t, "0px 0px 1px red, 0px 0px 1px inherit", result)
+	assertEqual(t, errors.New("Missing palette value: a"), err)
+}
+
+func TestShadowPaletteValues(t *testing.T) {
+	value := Shadows(
+		Shadow(PaletteValue("a"), 0, nil, PaletteValue("b")),
+		Shadow(0, 0, PX(1), "red").WithSpread(PaletteValue("c")),
+	)
+	assertEqual(t, []PaletteValue{"a", "b", "c"}, value.PaletteValues())
+}
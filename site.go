@@ -0,0 +1,233 @@
+package smetana
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// A static site that can be pre-compiled to a directory with [Site.Export].
+// Each route maps a URL path to a function creating the [Node] for that page,
+// and each palette in the [Smetana] context is written as a separate
+// stylesheet at `<StylesPath><palette>.css`. For example,
+//
+//	site := NewSite(&smetana, "https://example.com")
+//	site.Route("/", homePage)
+//	site.Route("/about", aboutPage)
+//	brokenLinks, err := site.Export("public")
+//
+// will write "public/index.html", "public/about/index.html", a stylesheet for
+// each palette and "public/sitemap.xml".
+//
+// Only routes with "pretty" URLs are included in the sitemap, so pages such
+// as "/404.html" aren't submitted for indexing. Other routes can be left out
+// of the sitemap with [Site.ExcludeFromSitemap].
+type Site struct {
+	Smetana                 *Smetana
+	BaseUrl                 string
+	Routes                  map[string]func() Node
+	StylesPath              string
+	SitemapExclude          map[string]bool
+	DeterministicAttributes bool
+	Logger                  *log.Logger
+}
+
+// Create a new [Site] for the given [Smetana] context. The base URL (ie;
+// "https://example.com") is used to generate the [Sitemap]. If it is empty
+// then no sitemap is generated.
+func NewSite(smetana *Smetana, baseUrl string) *Site {
+	return &Site{
+		Smetana:        smetana,
+		BaseUrl:        strings.TrimSuffix(baseUrl, "/"),
+		Routes:         map[string]func() Node{},
+		StylesPath:     "/styles/",
+		SitemapExclude: map[string]bool{},
+		Logger:         log.New(os.Stderr, "", 0),
+	}
+}
+
+// Add a page to a [Site] at the given path. Paths without a file extension
+// use "pretty" URLs, so "/about" is written to "about/index.html" and is
+// linked to as "/about/". Paths with an extension (ie; "/404.html") are
+// written as-is.
+func (site *Site) Route(path string, page func() Node) {
+	site.Routes[path] = page
+}
+
+// Leave the given routes out of the sitemap of a [Site], such as pages that
+// shouldn't be indexed by search engines. The routes are still exported.
+func (site *Site) ExcludeFromSitemap(routes ...string) {
+	if site.SitemapExclude == nil {
+		site.SitemapExclude = map[string]bool{}
+	}
+	for _, route := range routes {
+		urlPath, _ := sitePagePaths(route)
+		site.SitemapExclude[urlPath] = true
+	}
+}
+
+// Get the URL of the stylesheet for the given palette name.
+func (site *Site) StylesHref(palette string) string {
+	return site.StylesPath + palette + ".css"
+}
+
+// Create a [Handler] that serves the pages and stylesheets of a [Site]
+// dynamically instead of exporting them. Pretty URLs are served both with and
+// without a trailing slash (ie; "/about/" and "/about"). This is mostly
+// useful during development, for instance with a [DevServer].
+func (site *Site) Handler() *Handler {
+	handler := NewHandler(site.Smetana)
+	handler.StylesPath = site.StylesPath
+	handler.DeterministicAttributes = site.DeterministicAttributes
+	handler.Logger = site.Logger
+	for route, page := range site.Routes {
+		page := page
+		pageFunc := func(r *http.Request) Node {
+			return page()
+		}
+		urlPath, _ := sitePagePaths(route)
+		handler.Page(urlPath, pageFunc)
+		handler.Page(normalizeSitePath(urlPath), pageFunc)
+	}
+	return handler
+}
+
+// An internal link in an exported [Site] that doesn't match any page or
+// stylesheet.
+type BrokenLink struct {
+	Page string
+	Href string
+}
+
+// Get a [BrokenLink] as a string.
+func (link BrokenLink) Error() string {
+	return fmt.Sprintf("Broken link: %s (page: %s)", link.Href, link.Page)
+}
+
+// Get the URL path and the output file path for a route.
+func sitePagePaths(route string) (string, string) {
+	clean := path.Clean("/" + route)
+	if path.Ext(clean) != "" {
+		return clean, clean
+	}
+	if clean == "/" {
+		return "/", "/index.html"
+	}
+	return clean + "/", clean + "/index.html"
+}
+
+// Normalize a URL path so that equivalent paths can be compared.
+func normalizeSitePath(urlPath string) string {
+	urlPath = strings.TrimSuffix(path.Clean("/"+urlPath), "/index.html")
+	if urlPath == "" {
+		return "/"
+	}
+	return urlPath
+}
+
+// Get the sorted list of routes in a [Site].
+func (site *Site) routeNames() []string {
+	routes := make([]string, 0, len(site.Routes))
+	for route := range site.Routes {
+		routes = append(routes, route)
+	}
+	sort.Strings(routes)
+	return routes
+}
+
+// Write a single file to the output directory, creating any parent
+// directories as needed.
+func writeSiteFile(dir string, urlPath string, content string) error {
+	fullPath := filepath.Join(dir, filepath.FromSlash(urlPath))
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(fullPath, []byte(content), 0644)
+}
+
+var siteLinkRegexp = regexp.MustCompile(`<a\s[^>]*?\bhref="([^"]*)"`)
+
+// Export a [Site] to the given directory. All pages are rendered to HTML, a
+// stylesheet is written for each palette and, if a base URL is set, a
+// "sitemap.xml" is generated from the pages with "pretty" URLs that aren't
+// excluded with [Site.ExcludeFromSitemap].
+//
+// Links from `a` tags (ie; from [AHref]) to internal paths are checked
+// against the pages, stylesheets and sitemap of the [Site], and any that
+// don't match are returned as [BrokenLink]s. Broken links don't stop the
+// export. An error is only returned if a file could not be written.
+func (site *Site) Export(dir string) ([]BrokenLink, error) {
+	validPaths := map[string]bool{}
+	pageLinks := map[string][]string{}
+	sitemap := Sitemap{}
+	routes := site.routeNames()
+
+	for _, route := range routes {
+		urlPath, filePath := sitePagePaths(route)
+		html := RenderHtmlOpts(
+			site.Routes[route](),
+			site.DeterministicAttributes,
+			site.Logger,
+		)
+		if err := writeSiteFile(dir, filePath, html); err != nil {
+			return nil, err
+		}
+		validPaths[normalizeSitePath(urlPath)] = true
+		for _, match := range siteLinkRegexp.FindAllStringSubmatch(html, -1) {
+			pageLinks[urlPath] = append(pageLinks[urlPath], match[1])
+		}
+		if urlPath != filePath && !site.SitemapExclude[urlPath] {
+			sitemap = append(sitemap, SitemapLocationUrl(site.BaseUrl+urlPath))
+		}
+	}
+
+	for _, name := range site.Smetana.paletteNames() {
+		href := site.StylesHref(name)
+		css := RenderCssOpts(
+			site.Smetana.Styles,
+			site.Smetana.Palettes[name],
+			site.Logger,
+		)
+		if err := writeSiteFile(dir, href, css); err != nil {
+			return nil, err
+		}
+		validPaths[normalizeSitePath(href)] = true
+	}
+
+	if site.BaseUrl != "" {
+		xml := RenderSitemapOpts(sitemap, site.Logger)
+		if err := writeSiteFile(dir, "/sitemap.xml", xml); err != nil {
+			return nil, err
+		}
+		validPaths["/sitemap.xml"] = true
+	}
+
+	brokenLinks := []BrokenLink{}
+	for _, route := range routes {
+		urlPath, _ := sitePagePaths(route)
+		base := &url.URL{Path: urlPath}
+		for _, href := range pageLinks[urlPath] {
+			link, err := url.Parse(href)
+			if err != nil {
+				brokenLinks = append(brokenLinks, BrokenLink{urlPath, href})
+				continue
+			}
+			if link.Scheme != "" || link.Host != "" || link.Path == "" {
+				continue
+			}
+			target := normalizeSitePath(base.ResolveReference(link).Path)
+			if !validPaths[target] {
+				brokenLinks = append(brokenLinks, BrokenLink{urlPath, href})
+			}
+		}
+	}
+
+	return brokenLinks, nil
+}
@@ -0,0 +1,173 @@
+package smetana
+
+import (
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func newTestSite(baseUrl string) *Site {
+	smetana := NewSmetanaWithPalettes(Palettes{
+		"light": {"bg": Hex("#fff")},
+		"dark":  {"bg": Hex("#000")},
+	})
+	smetana.Styles.AddBlock("body", CssProps{{"background", PaletteValue("bg")}})
+	site := NewSite(&smetana, baseUrl)
+	site.Logger = log.New(io.Discard, "", 0)
+	site.DeterministicAttributes = true
+	return site
+}
+
+func readTestFile(t *testing.T, dir string, name string) string {
+	data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+	assertEqual(t, nil, err)
+	return string(data)
+}
+
+func TestSitePagePaths(t *testing.T) {
+	tests := map[string][2]string{
+		"/":          {"/", "/index.html"},
+		"":           {"/", "/index.html"},
+		"/about":     {"/about/", "/about/index.html"},
+		"/about/":    {"/about/", "/about/index.html"},
+		"blog/post":  {"/blog/post/", "/blog/post/index.html"},
+		"/404.html":  {"/404.html", "/404.html"},
+		"/feed.xml":  {"/feed.xml", "/feed.xml"},
+		"/a/../b/./": {"/b/", "/b/index.html"},
+	}
+	for route, expected := range tests {
+		urlPath, filePath := sitePagePaths(route)
+		assertEqual(t, expected[0], urlPath)
+		assertEqual(t, expected[1], filePath)
+	}
+}
+
+func TestSiteExport(t *testing.T) {
+	dir := t.TempDir()
+	site := newTestSite("https://example.com/")
+	site.Route("/", func() Node {
+		return Div(
+			AHref("/about/", "About"),
+			LinkStylesheet(site.StylesHref("light")),
+		)
+	})
+	site.Route("/about", func() Node {
+		return Div(AHref("/", "Home"))
+	})
+	site.Route("/404.html", func() Node {
+		return Div("Not found")
+	})
+	site.Route("/drafts", func() Node {
+		return Div("Drafts")
+	})
+	site.ExcludeFromSitemap("/drafts")
+
+	brokenLinks, err := site.Export(dir)
+	assertEqual(t, nil, err)
+	assertEqual(t, []BrokenLink{}, brokenLinks)
+
+	assertEqual(
+		t,
+		"<div><a href=\"/about/\">About</a><link href=\"/styles/light.css\" rel=\"stylesheet\"></div>",
+		readTestFile(t, dir, "index.html"),
+	)
+	assertEqual(t, "<div><a href=\"/\">Home</a></div>", readTestFile(t, dir, "about/index.html"))
+	assertEqual(t, "<div>Not found</div>", readTestFile(t, dir, "404.html"))
+	assertEqual(t, "<div>Drafts</div>", readTestFile(t, dir, "drafts/index.html"))
+	assertEqual(t, "body{background:#FFFFFF;}", readTestFile(t, dir, "styles/light.css"))
+	assertEqual(t, "body{background:#000000;}", readTestFile(t, dir, "styles/dark.css"))
+
+	expected := RenderSitemap(Sitemap{
+		SitemapLocationUrl("https://example.com/"),
+		SitemapLocationUrl("https://example.com/about/"),
+	})
+	assertEqual(t, expected, readTestFile(t, dir, "sitemap.xml"))
+}
+
+func TestSiteExportWithoutBaseUrlHasNoSitemap(t *testing.T) {
+	dir := t.TempDir()
+	site := newTestSite("")
+	site.Route("/", func() Node { return Div() })
+	_, err := site.Export(dir)
+	assertEqual(t, nil, err)
+	_, err = os.Stat(filepath.Join(dir, "sitemap.xml"))
+	assertEqual(t, true, os.IsNotExist(err))
+}
+
+func TestSiteExportReportsBrokenLinks(t *testing.T) {
+	site := newTestSite("https://example.com")
+	site.Route("/", func() Node {
+		return Div(
+			AHref("/about", "About"),
+			AHref("/about/index.html", "About"),
+			AHref("about?x=1#team", "About"),
+			AHref("/missing", "Missing"),
+			AHref("#top", "Top"),
+			AHref("https://example.org/missing", "External"),
+			AHref("mailto:hello@example.com", "Email"),
+			AHref("/sitemap.xml", "Sitemap"),
+			AHref("/styles/dark.css", "Styles"),
+			AHref("/index.html", "Home"),
+			AHref("%zz", "Invalid"),
+		)
+	})
+	site.Route("/about", func() Node {
+		return Div(AHref("../", "Home"), AHref("team", "Team"))
+	})
+
+	brokenLinks, err := site.Export(t.TempDir())
+	assertEqual(t, nil, err)
+	assertEqual(t, []BrokenLink{
+		{"/", "/missing"},
+		{"/", "%zz"},
+		{"/about/", "team"},
+	}, brokenLinks)
+	assertEqual(t, "Broken link: team (page: /about/)", brokenLinks[2].Error())
+}
+
+func TestSiteExclusionsWithoutNewSite(t *testing.T) {
+	site := Site{}
+	site.ExcludeFromSitemap("/drafts")
+	assertEqual(t, map[string]bool{"/drafts/": true}, site.SitemapExclude)
+}
+
+func TestSiteExportReturnsWriteErrors(t *testing.T) {
+	site := newTestSite("https://example.com")
+	site.Route("/", func() Node { return Div() })
+
+	// Block each output path in turn with a directory, or a file for the
+	// "styles" directory, so that writing to it fails
+	tests := map[string]bool{"index.html": true, "styles": false, "sitemap.xml": true}
+	for name, isDir := range tests {
+		dir := t.TempDir()
+		path := filepath.Join(dir, name)
+		if isDir {
+			assertEqual(t, nil, os.Mkdir(path, 0755))
+		} else {
+			assertEqual(t, nil, os.WriteFile(path, []byte{}, 0644))
+		}
+		brokenLinks, err := site.Export(dir)
+		assertNotEqual(t, nil, err)
+		assertEqual(t, 0, len(brokenLinks))
+	}
+}
+
+func TestSiteHandlerServesRoutes(t *testing.T) {
+	site := newTestSite("https://example.com")
+	site.Route("/about", func() Node { return Div("About") })
+	handler := site.Handler()
+	for _, path := range []string{"/about", "/about/"} {
+		response := serveTestRequest(handler, httptest.NewRequest("GET", path, nil))
+		assertEqual(t, http.StatusOK, response.StatusCode)
+		assertEqual(t, "<div>About</div>", readTestBody(t, response))
+	}
+	response := serveTestRequest(
+		handler,
+		httptest.NewRequest("GET", "/styles/dark.css", nil),
+	)
+	assertEqual(t, "body{background:#000000;}", readTestBody(t, response))
+}
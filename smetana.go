@@ -35,7 +35,11 @@
 
 package smetana
 
-import "log"
+import (
+	"fmt"
+	"log"
+	"sort"
+)
 
 // All structural elements of an HTML document are implementers of
 // the [Node] interface for converting to HTML. This is primarily
@@ -111,3 +115,55 @@ func (s Smetana) RenderStylesOpts(logger *log.Logger) map[string]string {
 	}
 	return result
 }
+
+// Get the names of all palettes in the [Smetana] context in sorted order.
+func (s Smetana) paletteNames() []string {
+	names := make([]string, 0, len(s.Palettes))
+	for name := range s.Palettes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// A [PaletteValue] used by the [StyleSheet] of a [Smetana] context that is
+// missing from one of its palettes.
+type MissingPaletteValue struct {
+	Palette string
+	Key     PaletteValue
+}
+
+// Convert a [MissingPaletteValue] to a human-readable string.
+func (missing MissingPaletteValue) Error() string {
+	return fmt.Sprintf(
+		"Missing palette value: %s (palette: %s)",
+		missing.Key,
+		missing.Palette,
+	)
+}
+
+// Check that every [PaletteValue] used in the [StyleSheet] (including inside
+// [PalettePrintfData]) exists in every [Palette] of the [Smetana] context.
+// Results are returned in order of palette name and then in order of first use
+// in the stylesheet. An empty result means all palettes are complete.
+func (s Smetana) ValidatePalettes() []MissingPaletteValue {
+	seen := map[PaletteValue]bool{}
+	keys := []PaletteValue{}
+	for _, key := range s.Styles.PaletteValues() {
+		if !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+
+	missing := []MissingPaletteValue{}
+	for _, name := range s.paletteNames() {
+		palette := s.Palettes[name]
+		for _, key := range keys {
+			if palette[string(key)] == nil {
+				missing = append(missing, MissingPaletteValue{name, key})
+			}
+		}
+	}
+	return missing
+}
@@ -76,3 +76,38 @@ func TestCanRenderStylesFromASmetanaContext(t *testing.T) {
 	assertEqual(t, "body{background:#FFFFFF;}", css["light"])
 	assertEqual(t, "body{background:#000000;}", css["dark"])
 }
+
+func TestCanValidatePalettes(t *testing.T) {
+	light := Palette{"bg": Hex("#fff"), "fg": Hex("#222")}
+	smetana := NewSmetanaWithPalettes(Palettes{
+		"light": light,
+		"dark":  light.Extend(Palette{"bg": Hex("#222")}),
+		"print": Palette{"fg": Hex("#000")},
+	})
+	smetana.Styles.AddBlock("body", CssProps{
+		{"background", PaletteValue("bg")},
+		{"color", PaletteValue("fg")},
+		{"border", PalettePrintf("1px solid %s", PaletteValue("border"))},
+		{"outline-color", PaletteValue("fg")},
+	})
+	missing := smetana.ValidatePalettes()
+	assertEqual(t, []MissingPaletteValue{
+		{"dark", "border"},
+		{"light", "border"},
+		{"print", "bg"},
+		{"print", "border"},
+	}, missing)
+	assertEqual(
+		t,
+		"Missing palette value: border (palette: dark)",
+		missing[0].Error(),
+	)
+}
+
+func TestValidPalettesHaveNoMissingValues(t *testing.T) {
+	smetana := NewSmetanaWithPalettes(Palettes{
+		"default": {"bg": Hex("#fff")},
+	})
+	smetana.Styles.AddBlock("body", CssProps{{"background", PaletteValue("bg")}})
+	assertEqual(t, 0, len(smetana.ValidatePalettes()))
+}
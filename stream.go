@@ -0,0 +1,206 @@
+package smetana
+
+import (
+	"context"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"time"
+)
+
+// A [Node] that is streamed to the client after the rest of the page. When
+// rendered with [RenderHtmlStream], the `Placeholder` is rendered and the
+// page is flushed immediately, then the `Content` is computed concurrently
+// and sent at the end of the response inside a `template` tag, along with a
+// small inline script that swaps it in place of the placeholder. Create a
+// [SuspenseNode] with [Suspense]:
+//
+//	Suspense(
+//		P("Loading recommendations..."),
+//		func(ctx context.Context) (Node, error) {
+//			items, err := fetchRecommendations(ctx)
+//			if err != nil {
+//				return nil, err
+//			}
+//			return RecommendationList(items), nil
+//		},
+//	).WithFallback(P("Recommendations are unavailable"))
+//
+// The placeholder is wrapped in a `div` with `display: contents` so that it
+// doesn't affect the layout. If the content fails and there is no fallback
+// then the placeholder is left in place.
+//
+// When rendered with other render functions, or when nested inside the
+// content of an [AsyncNode] or another [SuspenseNode], the placeholder is
+// ignored and the content is rendered in the same way as an [AsyncNode].
+type SuspenseNode struct {
+	Placeholder Node
+	Content     AsyncNode
+}
+
+// Create a [SuspenseNode] with a placeholder and a function that computes
+// the content.
+func Suspense(
+	placeholder Node,
+	render func(ctx context.Context) (Node, error),
+) SuspenseNode {
+	return SuspenseNode{placeholder, Async(render)}
+}
+
+// Set a [Node] to be streamed if the content of a [SuspenseNode] fails.
+func (node SuspenseNode) WithFallback(fallback Node) SuspenseNode {
+	node.Content = node.Content.WithFallback(fallback)
+	return node
+}
+
+// Set a timeout for computing the content of a [SuspenseNode].
+func (node SuspenseNode) WithTimeout(timeout time.Duration) SuspenseNode {
+	node.Content = node.Content.WithTimeout(timeout)
+	return node
+}
+
+// Convert a [SuspenseNode] to HTML.
+func (node SuspenseNode) ToHtml(b *Builder) {
+	if b.stream == nil {
+		node.Content.ToHtml(b)
+		return
+	}
+
+	id := b.stream.add()
+	b.Buf.WriteString("<div id=\"smetana-placeholder-")
+	b.Buf.WriteString(id)
+	b.Buf.WriteString("\" style=\"display:contents\">")
+	if node.Placeholder != nil {
+		node.Placeholder.ToHtml(b)
+	}
+	b.Buf.WriteString("</div>")
+
+	renderer := b.async.renderer
+	parent := renderer.newBuilder(b)
+	stream := b.stream
+	go func() {
+		result := renderer.run(node.Content, &parent)
+		swap := result.err == nil || node.Content.Fallback != nil
+		select {
+		case stream.results <- streamResult{id, swap, result}:
+		case <-renderer.ctx.Done():
+		}
+	}()
+}
+
+// Shared state for a single call to [RenderHtmlStream]. Only the `root`
+// [Builder] writes to the stream, but other builders for the same page (such
+// as those for [ErrorBoundaryNode]s) share the state to register
+// [SuspenseNode]s.
+type streamState struct {
+	root    *Builder
+	writer  io.Writer
+	count   int
+	pending int
+	results chan streamResult
+}
+
+type streamResult struct {
+	id   string
+	swap bool
+	asyncResult
+}
+
+// Register a new [SuspenseNode] and get its unique ID.
+func (stream *streamState) add() string {
+	id := strconv.Itoa(stream.count)
+	stream.count++
+	stream.pending++
+	return id
+}
+
+const streamSwapScript = "function smetanaSwap(i){" +
+	"var t=document.getElementById(\"smetana-content-\"+i)," +
+	"p=document.getElementById(\"smetana-placeholder-\"+i);" +
+	"if(t){if(p)p.replaceWith(t.content);t.remove()}}"
+
+// Render a [Node] as a stream of HTML to the given writer. The page is
+// flushed to the writer as soon as it has rendered, with placeholders for any
+// [SuspenseNode]s, and then the content for each [SuspenseNode] is sent as
+// soon as it's ready. If the writer is an [http.Flusher] (such as an
+// [http.ResponseWriter]) then it's flushed after each part so that the
+// client receives the page as soon as possible.
+//
+// The first error from an [AsyncNode] or [SuspenseNode] is returned, or an
+// error from the writer, or the context's error if it's cancelled before
+// all of the content has been sent.
+// See [RenderHtmlStreamOpts] for more fine-grained control.
+func RenderHtmlStream(ctx context.Context, w io.Writer, node Node) error {
+	return RenderHtmlStreamOpts(ctx, w, node, 0, false, nil)
+}
+
+// Render a [Node] as a stream of HTML to the given writer, specifying
+// particular settings for the internal [Builder]. The `concurrency` limit
+// works in the same way as in [RenderHtmlContextOpts].
+// See the [Builder] struct for the available configuration values.
+// See [RenderHtmlStream] for a simpler interface with default values.
+func RenderHtmlStreamOpts(
+	ctx context.Context,
+	w io.Writer,
+	node Node,
+	concurrency int,
+	deterministicAttrs bool,
+	logger *log.Logger,
+) error {
+	if logger == nil {
+		logger = log.New(os.Stderr, "", 0)
+	}
+	renderer := &asyncRenderer{ctx: ctx}
+	if concurrency > 0 {
+		renderer.slots = make(chan struct{}, concurrency)
+	}
+	builder := Builder{
+		DeterministicAttributes: deterministicAttrs,
+		Logger:                  logger,
+		ctx:                     ctx,
+		async:                   &asyncState{renderer: renderer},
+		stream: &streamState{
+			writer:  w,
+			results: make(chan streamResult),
+		},
+	}
+	builder.stream.root = &builder
+	node.ToHtml(&builder)
+	err := builder.Flush()
+
+	first := true
+	for builder.stream.pending > 0 {
+		select {
+		case result := <-builder.stream.results:
+			builder.stream.pending--
+			if err == nil {
+				err = result.err
+			}
+			if !result.swap {
+				continue
+			}
+			script := "smetanaSwap(\"" + result.id + "\")"
+			if first {
+				script = streamSwapScript + script
+				first = false
+			}
+			content := Template(
+				Attrs{"id": "smetana-content-" + result.id},
+				Text(result.html),
+			)
+			content.ToHtml(&builder)
+			Script(Text(script)).ToHtml(&builder)
+			if flushErr := builder.Flush(); err == nil {
+				err = flushErr
+			}
+		case <-ctx.Done():
+			if err == nil {
+				err = ctx.Err()
+			}
+			return err
+		}
+	}
+
+	return err
+}
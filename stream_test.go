@@ -0,0 +1,205 @@
+package smetana
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+// A writer that records each flushed chunk separately.
+type chunkWriter struct {
+	buf    strings.Builder
+	chunks []string
+}
+
+func (w *chunkWriter) Write(data []byte) (int, error) {
+	return w.buf.Write(data)
+}
+
+func (w *chunkWriter) Flush() {
+	w.chunks = append(w.chunks, w.buf.String())
+	w.buf.Reset()
+}
+
+func streamedContent(id string, html string, script string) string {
+	return "<template id=\"smetana-content-" + id + "\">" + html +
+		"</template><script>" + script + "smetanaSwap(\"" + id +
+		"\")</script>"
+}
+
+func TestStreamSendsPlaceholdersThenContent(t *testing.T) {
+	release := make(chan struct{})
+	node := Div(
+		Suspense(P("Loading..."), func(ctx context.Context) (Node, error) {
+			<-release
+			return P("Slow"), nil
+		}),
+		Suspense(nil, func(ctx context.Context) (Node, error) {
+			return P("Fast"), nil
+		}),
+		asyncText("async", 0),
+	)
+	var w chunkWriter
+	done := make(chan error)
+	go func() {
+		done <- RenderHtmlStream(context.Background(), &w, node)
+	}()
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	assertEqual(t, nil, <-done)
+	assertEqual(t, []string{
+		"<div><div id=\"smetana-placeholder-0\" style=\"display:contents\">" +
+			"<p>Loading...</p></div><div id=\"smetana-placeholder-1\" " +
+			"style=\"display:contents\"></div>async</div>",
+		streamedContent("1", "<p>Fast</p>", streamSwapScript),
+		streamedContent("0", "<p>Slow</p>", ""),
+	}, w.chunks)
+}
+
+func TestStreamErrors(t *testing.T) {
+	failure := errors.New("failed")
+	node := Div(
+		Suspense(P("a"), func(ctx context.Context) (Node, error) {
+			return nil, failure
+		}),
+		Suspense(P("b"), func(ctx context.Context) (Node, error) {
+			time.Sleep(20 * time.Millisecond)
+			return nil, failure
+		}).WithFallback(P("Fallback")),
+	)
+	var w chunkWriter
+	err := RenderHtmlStream(context.Background(), &w, node)
+	assertEqual(t, failure, err)
+	assertEqual(t, 2, len(w.chunks))
+	assertEqual(t, streamedContent("1", "<p>Fallback</p>", streamSwapScript), w.chunks[1])
+}
+
+func TestStreamCancellation(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	node := Suspense(P("Loading..."), func(ctx context.Context) (Node, error) {
+		time.Sleep(time.Minute)
+		return nil, nil
+	})
+	var w chunkWriter
+	err := RenderHtmlStream(ctx, &w, node)
+	assertEqual(t, context.DeadlineExceeded, err)
+	assertEqual(t, 1, len(w.chunks))
+}
+
+func TestStreamCancellationDoesNotLeakGoroutines(t *testing.T) {
+	goroutines := runtime.NumGoroutine()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	node := Suspense(P("Loading..."), func(ctx context.Context) (Node, error) {
+		// Finish after the stream has stopped waiting for the content
+		<-ctx.Done()
+		time.Sleep(20 * time.Millisecond)
+		return nil, ctx.Err()
+	})
+	var w chunkWriter
+	err := RenderHtmlStream(ctx, &w, node)
+	assertEqual(t, context.DeadlineExceeded, err)
+	deadline := time.Now().Add(5 * time.Second)
+	for runtime.NumGoroutine() > goroutines && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	assertEqual(t, true, runtime.NumGoroutine() <= goroutines)
+}
+
+func TestSuspenseNodeTimeout(t *testing.T) {
+	node := Suspense(P("Loading..."), func(ctx context.Context) (Node, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}).WithTimeout(10 * time.Millisecond).WithFallback(P("Timed out"))
+	var w chunkWriter
+	err := RenderHtmlStreamOpts(context.Background(), &w, node, 1, false, nil)
+	assertEqual(t, context.DeadlineExceeded, err)
+	assertEqual(t, []string{
+		"<div id=\"smetana-placeholder-0\" style=\"display:contents\">" +
+			"<p>Loading...</p></div>",
+		streamedContent("0", "<p>Timed out</p>", streamSwapScript),
+	}, w.chunks)
+}
+
+func TestStreamWriteErrors(t *testing.T) {
+	writer := &failingResponseWriter{http.Header{}}
+	err := RenderHtmlStream(context.Background(), writer, P("Hello"))
+	assertEqual(t, io.ErrClosedPipe, err)
+}
+
+func TestFlushWithoutStreamDoesNothing(t *testing.T) {
+	builder := NewBuilder(false, nil)
+	P("Hello").ToHtml(builder)
+	assertEqual(t, nil, builder.Flush())
+	assertEqual(t, "<p>Hello</p>", builder.Buf.String())
+}
+
+func TestSuspenseRendersContentWithoutStream(t *testing.T) {
+	node := Div(Suspense(P("Loading..."), func(ctx context.Context) (Node, error) {
+		return P("Content"), nil
+	}))
+	assertEqual(t, "<div><p>Content</p></div>", RenderHtml(node))
+	result, err := RenderHtmlContext(context.Background(), node)
+	assertEqual(t, nil, err)
+	assertEqual(t, "<div><p>Content</p></div>", result)
+}
+
+func TestHandlerCanStreamPages(t *testing.T) {
+	handler := newTestHandler()
+	handler.Stream = true
+	handler.Logger = log.New(io.Discard, "", 0)
+	handler.Page("/stream", func(r *http.Request) Node {
+		return Suspense(P("Loading..."), func(ctx context.Context) (Node, error) {
+			return P("Done"), nil
+		})
+	})
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest("GET", "/stream", nil))
+	assertEqual(t, true, recorder.Flushed)
+	assertEqual(t, "text/html; charset=utf-8", recorder.Header().Get("Content-Type"))
+	assertEqual(t, "", recorder.Header().Get("ETag"))
+	assertEqual(
+		t,
+		"<div id=\"smetana-placeholder-0\" style=\"display:contents\">"+
+			"<p>Loading...</p></div>"+
+			streamedContent("0", "<p>Done</p>", streamSwapScript),
+		recorder.Body.String(),
+	)
+}
+
+func TestHandlerStreamHeadRequestsHaveNoBody(t *testing.T) {
+	handler := newTestHandler()
+	handler.Stream = true
+	rendered := false
+	handler.Page("/stream", func(r *http.Request) Node {
+		rendered = true
+		return P("Hello")
+	})
+	response := serveTestRequest(handler, httptest.NewRequest("HEAD", "/stream", nil))
+	assertEqual(t, http.StatusOK, response.StatusCode)
+	assertEqual(t, "text/html; charset=utf-8", response.Header.Get("Content-Type"))
+	assertEqual(t, "", readTestBody(t, response))
+	assertEqual(t, false, rendered)
+}
+
+func TestHandlerLogsStreamErrors(t *testing.T) {
+	var target strings.Builder
+	handler := newTestHandler()
+	handler.Stream = true
+	handler.Logger = log.New(&target, "", 0)
+	handler.Page("/stream", func(r *http.Request) Node {
+		return Suspense(nil, func(ctx context.Context) (Node, error) {
+			return nil, errors.New("failed")
+		})
+	})
+	serveTestRequest(handler, httptest.NewRequest("GET", "/stream", nil))
+	assertEqual(t, "failed\n", target.String())
+}
@@ -3,6 +3,7 @@ package smetana
 import (
 	"fmt"
 	"path/filepath"
+	"strings"
 )
 
 // A single CSS property. For instance,
@@ -42,6 +43,19 @@ type ClassName string
 // and dark-mode.
 type Palette map[string]fmt.Stringer
 
+// Create a new [Palette] that inherits all of the values from an existing base
+// palette, replacing or adding the values in `overrides`. Neither the base
+// palette nor `overrides` are changed. For instance,
+//
+//	light := Palette{"bg": Hex("#fff"), "fg": Hex("#222"), "link": Hex("#00f")}
+//	dark := light.Extend(Palette{"bg": Hex("#222"), "fg": Hex("#eee")})
+func (palette Palette) Extend(overrides Palette) Palette {
+	result := make(Palette, len(palette)+len(overrides))
+	MergeMaps(result, palette)
+	MergeMaps(result, overrides)
+	return result
+}
+
 // Use [PaletteValue] when creating a [Stylesheet] to mark a value as needing
 // to be fetched from a [Palette].
 //
@@ -58,6 +72,31 @@ func (value PaletteValue) String() string {
 	return string(value)
 }
 
+// Interface for CSS values and [StyleSheetElement]s that reference values from
+// a [Palette]. Implementing this for custom types allows them to be checked by
+// [Smetana.ValidatePalettes].
+type PaletteDependent interface {
+	PaletteValues() []PaletteValue
+}
+
+// Get all of the [PaletteValue]s referenced by a CSS value.
+func cssValuePaletteValues(value any) []PaletteValue {
+	switch item := value.(type) {
+	case PaletteValue:
+		return []PaletteValue{item}
+	case PaletteDependent:
+		return item.PaletteValues()
+	}
+	return nil
+}
+
+// Interface for structured CSS values that need a [Palette] to be converted
+// into a string, such as [CssCalc]. Implementing this for custom types allows
+// them to be used as values in [CssProps].
+type CssValue interface {
+	ToCssValue(palette Palette) (string, error)
+}
+
 // Interface representing an abstract element to be inserted into a CSS
 // [StyleSheet].
 type StyleSheetElement interface {
@@ -160,6 +199,15 @@ func (block StyleSheetBlock) ToCss(builder *Builder, palette Palette) {
 	builder.Buf.WriteByte('}')
 }
 
+// Get all of the [PaletteValue]s referenced by a [StyleSheetBlock].
+func (block StyleSheetBlock) PaletteValues() []PaletteValue {
+	values := []PaletteValue{}
+	for _, prop := range block.Props {
+		values = append(values, cssValuePaletteValues(prop.Value)...)
+	}
+	return values
+}
+
 // A helper that allows you to format text including values from a [Palette].
 // This can be used fo cases such as:
 //
@@ -194,12 +242,21 @@ func (data PalettePrintfData) Render(palette Palette) string {
 	return fmt.Sprintf(data.Format, args...)
 }
 
+// Get all of the [PaletteValue]s referenced by a [PalettePrintfData].
+func (data PalettePrintfData) PaletteValues() []PaletteValue {
+	values := []PaletteValue{}
+	for _, arg := range data.Args {
+		values = append(values, cssValuePaletteValues(arg)...)
+	}
+	return values
+}
+
 // Convert the given CSS value into a string using the given [Palette] if
 // applicable.
 //
-// The value argument may be any of the following types: PaletteValue, string,
-// fmt.Stringer (which includes all of the Smetana unit types), or an int
-// (which will be interpreted as a quantity in pixels).
+// The value argument may be any of the following types: PaletteValue,
+// CssValue, string, fmt.Stringer (which includes all of the Smetana unit
+// types), or an int (which will be interpreted as a quantity in pixels).
 func CssValueToString(palette Palette, value any) (string, error) {
 	switch item := value.(type) {
 	case PaletteValue:
@@ -210,6 +267,8 @@ func CssValueToString(palette Palette, value any) (string, error) {
 		return insertion.String(), nil
 	case PalettePrintfData:
 		return item.Render(palette), nil
+	case CssValue:
+		return item.ToCssValue(palette)
 	case string:
 		return item, nil
 	case fmt.Stringer:
@@ -221,6 +280,22 @@ func CssValueToString(palette Palette, value any) (string, error) {
 	}
 }
 
+// Convert each of the given CSS values into a string with [CssValueToString]
+// and join them with the separator. Conversion continues after an error so
+// that a usable string is always returned, but the first error is reported.
+func joinCssValues(palette Palette, values []any, sep string) (string, error) {
+	var err error
+	strs := make([]string, len(values))
+	for i, value := range values {
+		str, valueErr := CssValueToString(palette, value)
+		if err == nil {
+			err = valueErr
+		}
+		strs[i] = str
+	}
+	return strings.Join(strs, sep), err
+}
+
 // Write the given value as a string to the [Builder], using the given
 // [Palette] is applicable. This is a low-level function that should rarely
 // be needed to be called directly by library consumers, but it's included in
@@ -299,3 +374,14 @@ func (styles StyleSheet) ToCss(builder *Builder, palette Palette) {
 		element.ToCss(builder, palette)
 	}
 }
+
+// Get all of the [PaletteValue]s referenced by a [StyleSheet], including any
+// nested [StyleSheet]s. Note that values used inside a [StyleSheetPaletteCss]
+// function can't be detected.
+func (styles StyleSheet) PaletteValues() []PaletteValue {
+	values := []PaletteValue{}
+	for _, element := range styles.Elements {
+		values = append(values, cssValuePaletteValues(element)...)
+	}
+	return values
+}
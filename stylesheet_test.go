@@ -227,3 +227,33 @@ func TestCanAddPaletteCss(t *testing.T) {
 	css := RenderCss(styles, palette)
 	assertEqual(t, "body{background:#FF00FF;}", css)
 }
+
+func TestCanExtendAPalette(t *testing.T) {
+	base := Palette{"bg": Hex("#fff"), "fg": Hex("#222"), "link": Hex("#00f")}
+	dark := base.Extend(Palette{"bg": Hex("#222"), "fg": Hex("#eee")})
+	assertEqual(t, Palette{
+		"bg":   Hex("#222"),
+		"fg":   Hex("#eee"),
+		"link": Hex("#00f"),
+	}, dark)
+	assertEqual(t, Palette{
+		"bg":   Hex("#fff"),
+		"fg":   Hex("#222"),
+		"link": Hex("#00f"),
+	}, base)
+}
+
+func TestCanGetStyleSheetPaletteValues(t *testing.T) {
+	styles := NewStyleSheet()
+	styles.AddCss("body{color:red;}")
+	styles.AddBlock("body", CssProps{
+		{"color", PaletteValue("fg")},
+		{"padding", PX(4)},
+		{"border", PalettePrintf("1px solid %s", PaletteValue("border"))},
+	})
+	styles.Elements = append(styles.Elements, NewStyleSheet(
+		StylesBlock("a", CssProps{{"color", PaletteValue("link")}}),
+	))
+	expected := []PaletteValue{"fg", "border", "link"}
+	assertEqual(t, expected, styles.PaletteValues())
+}
@@ -0,0 +1,419 @@
+package smetana
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// A value from a design tokens file that doesn't map to a more specific
+// Smetana type, such as a "number", "fontFamily" or "duration" token. The
+// value is kept as a CSS string along with its design token type so that it
+// can be exported again with [Palette.ToDesignTokens].
+type DesignToken struct {
+	Type  string
+	Value string
+}
+
+// Convert a [DesignToken] into a CSS string.
+func (token DesignToken) String() string {
+	return token.Value
+}
+
+// A [Palette] value that is an alias of another token, such as
+// "{color.brand}" in a design tokens file. It renders identically to the
+// value it refers to, but is exported as an alias by
+// [Palette.ToDesignTokens].
+type TokenAlias struct {
+	Name  string
+	Value fmt.Stringer
+}
+
+// Convert a [TokenAlias] into a CSS string.
+func (alias TokenAlias) String() string {
+	return alias.Value.String()
+}
+
+// Remove any [TokenAlias] wrappers from a [Palette] value.
+func unwrapTokenAlias(value fmt.Stringer) fmt.Stringer {
+	for {
+		alias, ok := value.(TokenAlias)
+		if !ok {
+			return value
+		}
+		value = alias.Value
+	}
+}
+
+// A CSS unit suffix and a function to create a Smetana unit type from a
+// value, used for "dimension" design tokens.
+var dimensionUnits = map[string]func(float32) fmt.Stringer{
+	"px":   func(v float32) fmt.Stringer { return PX(v) },
+	"rem":  func(v float32) fmt.Stringer { return REM(v) },
+	"em":   func(v float32) fmt.Stringer { return EM(v) },
+	"cm":   func(v float32) fmt.Stringer { return CM(v) },
+	"mm":   func(v float32) fmt.Stringer { return MM(v) },
+	"in":   func(v float32) fmt.Stringer { return IN(v) },
+	"pt":   func(v float32) fmt.Stringer { return PT(v) },
+	"pc":   func(v float32) fmt.Stringer { return PC(v) },
+	"ex":   func(v float32) fmt.Stringer { return EX(v) },
+	"ch":   func(v float32) fmt.Stringer { return CH(v) },
+	"vw":   func(v float32) fmt.Stringer { return VW(v) },
+	"vh":   func(v float32) fmt.Stringer { return VH(v) },
+	"vmin": func(v float32) fmt.Stringer { return VMin(v) },
+	"vmax": func(v float32) fmt.Stringer { return VMax(v) },
+	"%":    func(v float32) fmt.Stringer { return Perc(v) },
+}
+
+// Get the numeric value and unit suffix of a Smetana unit type.
+func dimensionValue(value fmt.Stringer) (float32, string, bool) {
+	switch item := value.(type) {
+	case PX:
+		return float32(item), "px", true
+	case REM:
+		return float32(item), "rem", true
+	case EM:
+		return float32(item), "em", true
+	case CM:
+		return float32(item), "cm", true
+	case MM:
+		return float32(item), "mm", true
+	case IN:
+		return float32(item), "in", true
+	case PT:
+		return float32(item), "pt", true
+	case PC:
+		return float32(item), "pc", true
+	case EX:
+		return float32(item), "ex", true
+	case CH:
+		return float32(item), "ch", true
+	case VW:
+		return float32(item), "vw", true
+	case VH:
+		return float32(item), "vh", true
+	case VMin:
+		return float32(item), "vmin", true
+	case VMax:
+		return float32(item), "vmax", true
+	case Perc:
+		return float32(item), "%", true
+	}
+	return 0, "", false
+}
+
+// A single design token found while walking a design tokens file, before
+// aliases have been resolved.
+type rawDesignToken struct {
+	Type  string
+	Value any
+}
+
+// Walk a design tokens group, recording every token in `tokens` under its
+// full dot-separated path. Group-level "$type" values are inherited by all
+// tokens in the group.
+func collectDesignTokens(
+	group map[string]any,
+	prefix string,
+	inheritedType string,
+	tokens map[string]rawDesignToken,
+) error {
+	if groupType, ok := group["$type"].(string); ok {
+		inheritedType = groupType
+	}
+	for key, child := range group {
+		if strings.HasPrefix(key, "$") {
+			continue
+		}
+		path := key
+		if len(prefix) > 0 {
+			path = prefix + "." + key
+		}
+		object, ok := child.(map[string]any)
+		if !ok {
+			return fmt.Errorf("Invalid design token: %s", path)
+		}
+		if value, isToken := object["$value"]; isToken {
+			tokenType := inheritedType
+			if ownType, ok := object["$type"].(string); ok {
+				tokenType = ownType
+			}
+			tokens[path] = rawDesignToken{tokenType, value}
+		} else if err := collectDesignTokens(object, path, inheritedType, tokens); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Get the name of the token referenced by an alias value like "{a.b}", if
+// the value is an alias.
+func designTokenAliasName(value any) (string, bool) {
+	str, ok := value.(string)
+	if !ok || !strings.HasPrefix(str, "{") || !strings.HasSuffix(str, "}") {
+		return "", false
+	}
+	return str[1 : len(str)-1], true
+}
+
+func parseDimensionToken(value any) (fmt.Stringer, error) {
+	var number float64
+	var unit string
+	switch item := value.(type) {
+	case string:
+		end := strings.IndexFunc(item, func(r rune) bool {
+			return (r < '0' || r > '9') && r != '.' && r != '-' && r != '+'
+		})
+		if end < 0 {
+			return nil, fmt.Errorf("Missing dimension unit: %s", item)
+		}
+		parsed, err := strconv.ParseFloat(item[:end], 64)
+		if err != nil {
+			return nil, err
+		}
+		number = parsed
+		unit = item[end:]
+	case map[string]any:
+		parsed, ok := item["value"].(float64)
+		if !ok {
+			return nil, fmt.Errorf("Invalid dimension value: %v", item["value"])
+		}
+		number = parsed
+		unit, _ = item["unit"].(string)
+	default:
+		return nil, fmt.Errorf("Invalid dimension: %v", value)
+	}
+	create, ok := dimensionUnits[unit]
+	if !ok {
+		return nil, fmt.Errorf("Unsupported dimension unit: %s", unit)
+	}
+	return create(float32(number)), nil
+}
+
+// Convert a design token value of the given type into a [Palette] value.
+func parseDesignTokenValue(tokenType string, value any) (fmt.Stringer, error) {
+	switch tokenType {
+	case "color":
+		str, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("Invalid color: %v", value)
+		}
+		return ParseColor(str)
+	case "dimension":
+		return parseDimensionToken(value)
+	}
+	switch item := value.(type) {
+	case string:
+		return DesignToken{tokenType, item}, nil
+	case float64:
+		return DesignToken{tokenType, strconv.FormatFloat(item, 'f', -1, 64)}, nil
+	case []any:
+		parts := make([]string, len(item))
+		for i, part := range item {
+			str, ok := part.(string)
+			if !ok {
+				return nil, fmt.Errorf("Unsupported design token value: %v", value)
+			}
+			parts[i] = str
+		}
+		return DesignToken{tokenType, strings.Join(parts, ", ")}, nil
+	}
+	return nil, fmt.Errorf("Unsupported design token value: %v", value)
+}
+
+// Resolve a single design token into a [Palette] value, following aliases.
+// `resolving` tracks the aliases currently being followed to detect cycles.
+func resolveDesignToken(
+	name string,
+	tokens map[string]rawDesignToken,
+	palette Palette,
+	resolving map[string]bool,
+) (fmt.Stringer, string, error) {
+	token, ok := tokens[name]
+	if !ok {
+		return nil, "", fmt.Errorf("Unknown design token: %s", name)
+	}
+	if value, done := palette[name]; done {
+		return value, token.Type, nil
+	}
+	if resolving[name] {
+		return nil, "", fmt.Errorf("Circular design token alias: %s", name)
+	}
+	resolving[name] = true
+	defer delete(resolving, name)
+
+	var value fmt.Stringer
+	var err error
+	if target, isAlias := designTokenAliasName(token.Value); isAlias {
+		var targetValue fmt.Stringer
+		var targetType string
+		targetValue, targetType, err = resolveDesignToken(target, tokens, palette, resolving)
+		if len(token.Type) < 1 {
+			token.Type = targetType
+		}
+		value = TokenAlias{target, targetValue}
+	} else {
+		value, err = parseDesignTokenValue(token.Type, token.Value)
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("Invalid design token %s: %w", name, err)
+	}
+	palette[name] = value
+	return value, token.Type, nil
+}
+
+// Convert a group of design tokens into a [Palette].
+func designTokensToPalette(group map[string]any) (Palette, error) {
+	tokens := map[string]rawDesignToken{}
+	if err := collectDesignTokens(group, "", "", tokens); err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(tokens))
+	for name := range tokens {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	palette := Palette{}
+	for _, name := range names {
+		_, _, err := resolveDesignToken(name, tokens, palette, map[string]bool{})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return palette, nil
+}
+
+// Load a [Palette] from a JSON file in the W3C Design Tokens format (see
+// https://www.designtokens.org). Each token is added to the palette with its
+// full dot-separated path as the key, so the token "brand" in the group
+// "color" can be used as `PaletteValue("color.brand")`.
+//
+// Token values are converted to Smetana types where possible:
+//   - "color" tokens are parsed with [ParseColor]
+//   - "dimension" tokens are converted to unit types such as [PX] and [REM]
+//   - aliases such as "{color.brand}" become a [TokenAlias]
+//   - other string, number and string array values become a [DesignToken]
+//
+// Group "$type" values are inherited by the tokens in the group.
+func ParseDesignTokens(data []byte) (Palette, error) {
+	var group map[string]any
+	if err := json.Unmarshal(data, &group); err != nil {
+		return nil, err
+	}
+	return designTokensToPalette(group)
+}
+
+// Load multiple [Palettes] from a JSON file in the W3C Design Tokens format.
+// Each top-level group in the file is loaded as a separate palette with the
+// group name as the palette name (ie; "light" and "dark"). Aliases are
+// resolved within each palette, so "{color.brand}" in the "dark" group refers
+// to "dark.color.brand". See [ParseDesignTokens] for details of how tokens are
+// converted.
+func ParseDesignTokenPalettes(data []byte) (Palettes, error) {
+	var groups map[string]map[string]any
+	if err := json.Unmarshal(data, &groups); err != nil {
+		return nil, err
+	}
+	palettes := Palettes{}
+	for name, group := range groups {
+		palette, err := designTokensToPalette(group)
+		if err != nil {
+			return nil, fmt.Errorf("Palette %s: %w", name, err)
+		}
+		palettes[name] = palette
+	}
+	return palettes, nil
+}
+
+// Format a [Color] as a hex string for a design tokens file, including the
+// alpha channel only if the color is translucent.
+func colorToHexToken(color Color) string {
+	rgba := color.ToRgba()
+	if rgba.A == 255 {
+		return fmt.Sprintf("#%02x%02x%02x", rgba.R, rgba.G, rgba.B)
+	}
+	return fmt.Sprintf("#%02x%02x%02x%02x", rgba.R, rgba.G, rgba.B, rgba.A)
+}
+
+// Convert a [Palette] value into a design token JSON object.
+func paletteValueToDesignToken(value fmt.Stringer) map[string]any {
+	if alias, ok := value.(TokenAlias); ok {
+		return map[string]any{"$value": "{" + alias.Name + "}"}
+	}
+	if token, ok := value.(DesignToken); ok {
+		return map[string]any{"$type": token.Type, "$value": token.Value}
+	}
+	if color, ok := value.(Color); ok {
+		return map[string]any{"$type": "color", "$value": colorToHexToken(color)}
+	}
+	if number, unit, ok := dimensionValue(value); ok {
+		return map[string]any{
+			"$type":  "dimension",
+			"$value": map[string]any{"value": number, "unit": unit},
+		}
+	}
+	return map[string]any{"$value": value.String()}
+}
+
+// Convert a [Palette] into a design tokens group, splitting keys on "." to
+// create nested groups.
+func paletteToDesignTokens(palette Palette) (map[string]any, error) {
+	// Keys are sorted so that a token is always seen before any group that
+	// shares its name
+	keys := make([]string, 0, len(palette))
+	for key := range palette {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	root := map[string]any{}
+	for _, key := range keys {
+		path := strings.Split(key, ".")
+		group := root
+		for i, name := range path[:len(path)-1] {
+			child, ok := group[name].(map[string]any)
+			if !ok {
+				child = map[string]any{}
+				group[name] = child
+			} else if _, isToken := child["$value"]; isToken {
+				return nil, fmt.Errorf(
+					"Design token is also a group: %s",
+					strings.Join(path[:i+1], "."),
+				)
+			}
+			group = child
+		}
+		group[path[len(path)-1]] = paletteValueToDesignToken(palette[key])
+	}
+	return root, nil
+}
+
+// Export a [Palette] as a JSON file in the W3C Design Tokens format. This is
+// the inverse of [ParseDesignTokens]: keys are split on "." into nested
+// groups, colors are exported as hex strings, unit types are exported as
+// "dimension" tokens, and [TokenAlias] values are exported as aliases.
+func (palette Palette) ToDesignTokens() ([]byte, error) {
+	root, err := paletteToDesignTokens(palette)
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(root, "", "  ")
+}
+
+// Export [Palettes] as a JSON file in the W3C Design Tokens format, with one
+// top-level group per palette. This is the inverse of
+// [ParseDesignTokenPalettes].
+func (palettes Palettes) ToDesignTokens() ([]byte, error) {
+	root := map[string]any{}
+	for name, palette := range palettes {
+		group, err := paletteToDesignTokens(palette)
+		if err != nil {
+			return nil, fmt.Errorf("Palette %s: %w", name, err)
+		}
+		root[name] = group
+	}
+	return json.MarshalIndent(root, "", "  ")
+}
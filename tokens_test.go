@@ -0,0 +1,204 @@
+package smetana
+
+import "testing"
+
+const testDesignTokens = `{
+	"color": {
+		"$type": "color",
+		"brand": {"$value": "#3b82f6"},
+		"text": {"$value": "rgb(34 34 34 / 50%)", "$description": "Body text"},
+		"link": {"$value": "{color.brand}"}
+	},
+	"space": {
+		"$type": "dimension",
+		"sm": {"$value": "4px"},
+		"md": {"$value": {"value": 1.5, "unit": "rem"}},
+		"gutter": {"$value": "{space.md}"}
+	},
+	"font": {
+		"body": {"$type": "fontFamily", "$value": ["Open Sans", "sans-serif"]},
+		"weight": {"$type": "fontWeight", "$value": 700},
+		"speed": {"$type": "duration", "$value": "200ms"}
+	}
+}`
+
+func TestCanParseDesignTokens(t *testing.T) {
+	palette, err := ParseDesignTokens([]byte(testDesignTokens))
+	assertEqual(t, nil, err)
+	assertEqual(t, Palette{
+		"color.brand":  RGB{0x3b, 0x82, 0xf6},
+		"color.text":   RGBA{34, 34, 34, 128},
+		"color.link":   TokenAlias{"color.brand", RGB{0x3b, 0x82, 0xf6}},
+		"space.sm":     PX(4),
+		"space.md":     REM(1.5),
+		"space.gutter": TokenAlias{"space.md", REM(1.5)},
+		"font.body":    DesignToken{"fontFamily", "Open Sans, sans-serif"},
+		"font.weight":  DesignToken{"fontWeight", "700"},
+		"font.speed":   DesignToken{"duration", "200ms"},
+	}, palette)
+}
+
+func TestDesignTokensCanBeUsedInStyleSheets(t *testing.T) {
+	palette, err := ParseDesignTokens([]byte(testDesignTokens))
+	assertEqual(t, nil, err)
+	styles := NewStyleSheet(StylesBlock("a", CssProps{
+		{"color", PaletteValue("color.link")},
+		{"padding", PaletteValue("space.gutter")},
+		{"font-family", PaletteValue("font.body")},
+	}))
+	expected := "a{color:#3B82F6;padding:1.50rem;font-family:Open Sans, sans-serif;}"
+	assertEqual(t, expected, RenderCss(styles, palette))
+}
+
+func TestCanParseDesignTokenPalettes(t *testing.T) {
+	palettes, err := ParseDesignTokenPalettes([]byte(`{
+		"light": {"bg": {"$type": "color", "$value": "#fff"}},
+		"dark": {
+			"base": {"$type": "color", "$value": "#000"},
+			"bg": {"$value": "{base}"}
+		}
+	}`))
+	assertEqual(t, nil, err)
+	assertEqual(t, Palettes{
+		"light": {"bg": RGB{255, 255, 255}},
+		"dark": {
+			"base": RGB{0, 0, 0},
+			"bg":   TokenAlias{"base", RGB{0, 0, 0}},
+		},
+	}, palettes)
+}
+
+func TestParseInvalidDesignTokens(t *testing.T) {
+	tests := map[string]string{
+		`not json`:                               "invalid character 'o' in literal null (expecting 'u')",
+		`{"a": 1}`:                               "Invalid design token: a",
+		`{"a": {"$type": "color", "$value": 1}}`: "Invalid design token a: Invalid color: 1",
+		`{"a": {"$type": "color", "$value": "nope"}}`:  "Invalid design token a: Invalid color: nope",
+		`{"a": {"$type": "dimension", "$value": "4"}}`: "Invalid design token a: Missing dimension unit: 4",
+		`{"a": {"$type": "dimension", "$value": "-px"}}`: "Invalid design token a: " +
+			"strconv.ParseFloat: parsing \"-\": invalid syntax",
+		`{"a": {"$type": "dimension", "$value": "4zz"}}`: "Invalid design token a: Unsupported dimension unit: zz",
+		`{"a": {"$type": "dimension", "$value": {"value": "x"}}}`: "Invalid design token a: " +
+			"Invalid dimension value: x",
+		`{"a": {"$type": "dimension", "$value": true}}`: "Invalid design token a: Invalid dimension: true",
+		`{"a": {"$value": {"b": 1}}}`:                   "Invalid design token a: Unsupported design token value: map[b:1]",
+		`{"a": {"$value": [1]}}`:                        "Invalid design token a: Unsupported design token value: [1]",
+		`{"a": {"$value": "{b}"}}`:                      "Invalid design token a: Unknown design token: b",
+		`{"a": {"$value": "{b}"}, "b": {"$value": "{a}"}}`: "Invalid design token a: " +
+			"Invalid design token b: Circular design token alias: a",
+		`{"g": {"a": 1}}`: "Invalid design token: g.a",
+	}
+	for input, expected := range tests {
+		palette, err := ParseDesignTokens([]byte(input))
+		assertEqual(t, nil, palette)
+		assertEqual(t, expected, err.Error())
+	}
+}
+
+func TestParseInvalidDesignTokenPalettes(t *testing.T) {
+	palettes, err := ParseDesignTokenPalettes([]byte(`[]`))
+	assertEqual(t, nil, palettes)
+	assertNotEqual(t, nil, err)
+	palettes, err = ParseDesignTokenPalettes([]byte(`{"dark": {"a": 1}}`))
+	assertEqual(t, nil, palettes)
+	assertEqual(t, "Palette dark: Invalid design token: a", err.Error())
+}
+
+func TestCanExportDesignTokens(t *testing.T) {
+	palette := Palette{
+		"color.brand": Hex("#3b82f6"),
+		"color.text":  Rgba(34, 34, 34, 128),
+		"color.link":  TokenAlias{"color.brand", Hex("#3b82f6")},
+		"space.md":    REM(1.5),
+		"font":        DesignToken{"fontFamily", "serif"},
+		"other":       PaletteValue("foo"),
+	}
+	data, err := palette.ToDesignTokens()
+	assertEqual(t, nil, err)
+	expected := `{
+  "color": {
+    "brand": {
+      "$type": "color",
+      "$value": "#3b82f6"
+    },
+    "link": {
+      "$value": "{color.brand}"
+    },
+    "text": {
+      "$type": "color",
+      "$value": "#22222280"
+    }
+  },
+  "font": {
+    "$type": "fontFamily",
+    "$value": "serif"
+  },
+  "other": {
+    "$value": "foo"
+  },
+  "space": {
+    "md": {
+      "$type": "dimension",
+      "$value": {
+        "unit": "rem",
+        "value": 1.5
+      }
+    }
+  }
+}`
+	assertEqual(t, expected, string(data))
+}
+
+func TestDesignTokensRoundTrip(t *testing.T) {
+	palette, err := ParseDesignTokens([]byte(testDesignTokens))
+	assertEqual(t, nil, err)
+	data, err := palette.ToDesignTokens()
+	assertEqual(t, nil, err)
+	result, err := ParseDesignTokens(data)
+	assertEqual(t, nil, err)
+	assertEqual(t, palette, result)
+}
+
+func TestExportAllDimensionUnits(t *testing.T) {
+	for unit, create := range dimensionUnits {
+		value := create(2)
+		number, resultUnit, ok := dimensionValue(value)
+		assertEqual(t, true, ok)
+		assertEqual(t, float32(2), number)
+		assertEqual(t, unit, resultUnit)
+	}
+}
+
+func TestExportConflictingDesignTokens(t *testing.T) {
+	palette := Palette{"a": PX(1), "a.b": PX(2)}
+	_, err := palette.ToDesignTokens()
+	assertEqual(t, "Design token is also a group: a", err.Error())
+	_, err = Palettes{"dark": palette}.ToDesignTokens()
+	assertEqual(t, "Palette dark: Design token is also a group: a", err.Error())
+	palette = Palette{"x.a": PX(1), "x.a.b": PX(2)}
+	_, err = palette.ToDesignTokens()
+	assertEqual(t, "Design token is also a group: x.a", err.Error())
+}
+
+func TestCanExportDesignTokenPalettes(t *testing.T) {
+	palettes := Palettes{
+		"light": {"bg": Hex("#fff")},
+		"dark":  {"bg": Hex("#000")},
+	}
+	data, err := palettes.ToDesignTokens()
+	assertEqual(t, nil, err)
+	result, err := ParseDesignTokenPalettes(data)
+	assertEqual(t, nil, err)
+	assertEqual(t, palettes, result)
+}
+
+func TestContrastUnwrapsTokenAliases(t *testing.T) {
+	smetana := NewSmetanaWithPalettes(Palettes{
+		"default": {
+			"fg": TokenAlias{"black", TokenAlias{"base", Hex("#000")}},
+			"bg": Hex("#fff"),
+		},
+	})
+	failures := smetana.CheckContrast([]ContrastPair{{"fg", "bg"}}, ContrastAAA)
+	assertEqual(t, 0, len(failures))
+}
@@ -0,0 +1,185 @@
+package smetana
+
+import "strings"
+
+// A single CSS transform function such as `translate()` or `rotate()`,
+// implementing [CssValue]. Arguments can be any type supported by
+// [CssValueToString], so an `int` is rendered in pixels as elsewhere, except
+// in the `rotate` and `skew` functions where it's rendered in degrees. Other
+// angles should be given with a unit such as [Rad]. The arguments of the
+// `scale` and `matrix` functions are unitless, so plain numbers (int, float32
+// or float64) are rendered without units there. Transform functions are
+// usually created with the helpers such as [Translate] and [Rotate], and are
+// combined with [Transforms].
+type CssTransform struct {
+	Name string
+	Args []any
+}
+
+// Create a `translate()` transform.
+func Translate(x any, y any) CssTransform {
+	return CssTransform{"translate", []any{x, y}}
+}
+
+// Create a `translateX()` transform.
+func TranslateX(x any) CssTransform {
+	return CssTransform{"translateX", []any{x}}
+}
+
+// Create a `translateY()` transform.
+func TranslateY(y any) CssTransform {
+	return CssTransform{"translateY", []any{y}}
+}
+
+// Create a `translate3d()` transform.
+func Translate3d(x any, y any, z any) CssTransform {
+	return CssTransform{"translate3d", []any{x, y, z}}
+}
+
+// Create a `scale()` transform.
+func Scale(x any, y any) CssTransform {
+	return CssTransform{"scale", []any{x, y}}
+}
+
+// Create a `scaleX()` transform.
+func ScaleX(x any) CssTransform {
+	return CssTransform{"scaleX", []any{x}}
+}
+
+// Create a `scaleY()` transform.
+func ScaleY(y any) CssTransform {
+	return CssTransform{"scaleY", []any{y}}
+}
+
+// Create a `rotate()` transform. The angle is usually a [Deg] or a [Turn], and
+// an `int` is treated as degrees.
+func Rotate(angle any) CssTransform {
+	return CssTransform{"rotate", []any{angle}}
+}
+
+// Create a `skew()` transform. An `int` angle is treated as degrees.
+func Skew(x any, y any) CssTransform {
+	return CssTransform{"skew", []any{x, y}}
+}
+
+// Create a `skewX()` transform. An `int` angle is treated as degrees.
+func SkewX(angle any) CssTransform {
+	return CssTransform{"skewX", []any{angle}}
+}
+
+// Create a `skewY()` transform. An `int` angle is treated as degrees.
+func SkewY(angle any) CssTransform {
+	return CssTransform{"skewY", []any{angle}}
+}
+
+// Create a `perspective()` transform.
+func Perspective(distance any) CssTransform {
+	return CssTransform{"perspective", []any{distance}}
+}
+
+// Create a `matrix()` transform.
+func Matrix(a, b, c, d, tx, ty float64) CssTransform {
+	return CssTransform{"matrix", []any{a, b, c, d, tx, ty}}
+}
+
+// The transform functions whose arguments are plain numbers rather than
+// lengths or angles.
+var unitlessTransforms = map[string]bool{
+	"scale":    true,
+	"scaleX":   true,
+	"scaleY":   true,
+	"scaleZ":   true,
+	"scale3d":  true,
+	"matrix":   true,
+	"matrix3d": true,
+}
+
+// The transform functions whose arguments are angles, so that an int can be
+// rendered in degrees rather than pixels.
+var angleTransforms = map[string]bool{
+	"rotate":  true,
+	"rotateX": true,
+	"rotateY": true,
+	"rotateZ": true,
+	"skew":    true,
+	"skewX":   true,
+	"skewY":   true,
+}
+
+// Convert a [CssTransform] into a CSS string.
+func (transform CssTransform) ToCssValue(palette Palette) (string, error) {
+	var err error
+	args := make([]string, len(transform.Args))
+	for i, arg := range transform.Args {
+		var str string
+		var argErr error
+		switch value := arg.(type) {
+		case int:
+			if unitlessTransforms[transform.Name] {
+				str, argErr = mathExprToString(palette, arg, false)
+			} else if angleTransforms[transform.Name] {
+				str = Deg(value).String()
+			} else {
+				str, argErr = CssValueToString(palette, arg)
+			}
+		case float32, float64:
+			if unitlessTransforms[transform.Name] {
+				str, argErr = mathExprToString(palette, arg, false)
+			} else {
+				str, argErr = CssValueToString(palette, arg)
+			}
+		default:
+			str, argErr = CssValueToString(palette, arg)
+		}
+		if err == nil {
+			err = argErr
+		}
+		args[i] = str
+	}
+	return transform.Name + "(" + strings.Join(args, ", ") + ")", err
+}
+
+// Get all of the [PaletteValue]s referenced by a [CssTransform].
+func (transform CssTransform) PaletteValues() []PaletteValue {
+	values := []PaletteValue{}
+	for _, arg := range transform.Args {
+		values = append(values, cssValuePaletteValues(arg)...)
+	}
+	return values
+}
+
+// A list of [CssTransform]s implementing [CssValue], for use with the CSS
+// `transform` property. For example,
+//
+//	CssProps{{"transform", Transforms(TranslateX(Perc(-50)), Rotate(Deg(45)))}}
+//
+// will compile to `transform: translateX(-50%) rotate(45deg)`. Transforms are
+// applied in the order given.
+type CssTransforms []CssTransform
+
+// Create a [CssTransforms] list.
+func Transforms(transforms ...CssTransform) CssTransforms {
+	return transforms
+}
+
+// Convert a [CssTransforms] list into a CSS string. An empty list is
+// rendered as "none".
+func (transforms CssTransforms) ToCssValue(palette Palette) (string, error) {
+	if len(transforms) == 0 {
+		return "none", nil
+	}
+	values := make([]any, len(transforms))
+	for i, transform := range transforms {
+		values[i] = transform
+	}
+	return joinCssValues(palette, values, " ")
+}
+
+// Get all of the [PaletteValue]s referenced by a [CssTransforms] list.
+func (transforms CssTransforms) PaletteValues() []PaletteValue {
+	values := []PaletteValue{}
+	for _, transform := range transforms {
+		values = append(values, transform.PaletteValues()...)
+	}
+	return values
+}
@@ -0,0 +1,78 @@
+package smetana
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRenderTransforms(t *testing.T) {
+	palette := Palette{"offset": PX(4)}
+	tests := map[string]any{
+		"translate(10px, 50%)":           Translate(PX(10), Perc(50)),
+		"translateX(-50%)":               TranslateX(Perc(-50)),
+		"translateY(4px)":                TranslateY(PaletteValue("offset")),
+		"translate3d(1px, 2px, 3px)":     Translate3d(PX(1), PX(2), PX(3)),
+		"translate(10px, 20px)":          Translate(10, 20),
+		"perspective(0px)":               Perspective(0),
+		"scale(2, 0.50)":                 Scale(2, 0.5),
+		"scaleX(1.50)":                   ScaleX(1.5),
+		"scaleY(-1)":                     ScaleY(-1),
+		"rotate(45deg)":                  Rotate(Deg(45)),
+		"skew(10deg, 0.10rad)":           Skew(Deg(10), Rad(0.1)),
+		"skewX(5deg)":                    SkewX(Deg(5)),
+		"skewY(0.50turn)":                SkewY(Turn(0.5)),
+		"rotate(-90deg)":                 Rotate(-90),
+		"skewX(10deg)":                   SkewX(10),
+		"skew(10deg, -5deg)":             Skew(10, -5),
+		"perspective(100px)":             Perspective(PX(100)),
+		"matrix(1, 0, 0, 1, 10.50, 0)":   Matrix(1, 0, 0, 1, 10.5, 0),
+		"translateX(calc(100% - 4px))":   TranslateX(Calc(Perc(100)).Sub(PaletteValue("offset"))),
+		"none":                           Transforms(),
+		"translateX(-50%) rotate(45deg)": Transforms(TranslateX(Perc(-50)), Rotate(Deg(45))),
+	}
+	for expected, value := range tests {
+		result, err := CssValueToString(palette, value)
+		assertEqual(t, nil, err)
+		assertEqual(t, expected, result)
+	}
+}
+
+func TestTransformsCanBeUsedInStyleSheets(t *testing.T) {
+	styles := NewStyleSheet(StylesBlock(".spin", CssProps{
+		{"transform", Transforms(Rotate(Deg(90)), Scale(2, 2))},
+	}))
+	expected := ".spin{transform:rotate(90deg) scale(2, 2);}"
+	assertEqual(t, expected, RenderCss(styles, Palette{}))
+}
+
+func TestTransformsReportMissingPaletteValues(t *testing.T) {
+	value := Transforms(Rotate(Deg(5)), TranslateX(PaletteValue("a")))
+	result, err := CssValueToString(Palette{}, value)
+	assertEqual(t, "rotate(5deg) translateX(inherit)", result)
+	assertEqual(t, errors.New("Missing palette value: a"), err)
+}
+
+func TestTransformPaletteValues(t *testing.T) {
+	value := Transforms(
+		Translate(PaletteValue("a"), PX(1)),
+		Rotate(Deg(5)),
+		TranslateY(Calc(PaletteValue("b")).Mul(2)),
+	)
+	assertEqual(t, []PaletteValue{"a", "b"}, value.PaletteValues())
+}
+
+func TestTransformsRejectUnitlessFloatLengths(t *testing.T) {
+	_, err := CssValueToString(Palette{}, Translate(1.5, PX(2)))
+	assertEqual(t, "Invalid CSS value: 1.5", err.Error())
+	_, err = CssValueToString(Palette{}, Rotate(float32(45)))
+	assertEqual(t, "Invalid CSS value: 45", err.Error())
+}
+
+func TestTransformsRenderIntAnglesInDegrees(t *testing.T) {
+	result, err := CssValueToString(Palette{}, Rotate(45))
+	assertEqual(t, nil, err)
+	assertEqual(t, "rotate(45deg)", result)
+	result, err = CssValueToString(Palette{}, SkewX(10))
+	assertEqual(t, nil, err)
+	assertEqual(t, "skewX(10deg)", result)
+}
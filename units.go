@@ -2,11 +2,20 @@ package smetana
 
 import "fmt"
 
-func formatFloatUnits[T ~float32](value T, suffix string) string {
+// The number of decimal places used when formatting non-integer unit values
+// such as [PX] and [Deg], and numbers in CSS math functions such as [Calc].
+// Integer values are always formatted without a decimal point.
+//
+// This is read without synchronization while rendering, so it must only be
+// set once during initialization, before any rendering starts (including
+// concurrent rendering with [RenderHtmlContext] or a [Handler]).
+var UnitPrecision = 2
+
+func formatFloatUnits[T ~float32 | ~float64](value T, suffix string) string {
 	if value == T(int(value)) {
 		return fmt.Sprintf("%d%s", int(value), suffix)
 	}
-	return fmt.Sprintf("%.2f%s", value, suffix)
+	return fmt.Sprintf("%.*f%s", UnitPrecision, value, suffix)
 }
 
 // Utility type for marking CSS values as pixels (adds a "px" suffix).
@@ -113,3 +122,228 @@ type Perc float32
 func (value Perc) String() string {
 	return formatFloatUnits(value, "%")
 }
+
+// Utility type for marking CSS values as dynamic viewport width (adds a "dvw"
+// suffix).
+type DVW float32
+
+func (value DVW) String() string {
+	return formatFloatUnits(value, "dvw")
+}
+
+// Utility type for marking CSS values as dynamic viewport height (adds a "dvh"
+// suffix).
+type DVH float32
+
+func (value DVH) String() string {
+	return formatFloatUnits(value, "dvh")
+}
+
+// Utility type for marking CSS values as dvmin (adds a "dvmin" suffix).
+type DVMin float32
+
+func (value DVMin) String() string {
+	return formatFloatUnits(value, "dvmin")
+}
+
+// Utility type for marking CSS values as dvmax (adds a "dvmax" suffix).
+type DVMax float32
+
+func (value DVMax) String() string {
+	return formatFloatUnits(value, "dvmax")
+}
+
+// Utility type for marking CSS values as small viewport width (adds a "svw"
+// suffix).
+type SVW float32
+
+func (value SVW) String() string {
+	return formatFloatUnits(value, "svw")
+}
+
+// Utility type for marking CSS values as small viewport height (adds a "svh"
+// suffix).
+type SVH float32
+
+func (value SVH) String() string {
+	return formatFloatUnits(value, "svh")
+}
+
+// Utility type for marking CSS values as svmin (adds a "svmin" suffix).
+type SVMin float32
+
+func (value SVMin) String() string {
+	return formatFloatUnits(value, "svmin")
+}
+
+// Utility type for marking CSS values as svmax (adds a "svmax" suffix).
+type SVMax float32
+
+func (value SVMax) String() string {
+	return formatFloatUnits(value, "svmax")
+}
+
+// Utility type for marking CSS values as large viewport width (adds a "lvw"
+// suffix).
+type LVW float32
+
+func (value LVW) String() string {
+	return formatFloatUnits(value, "lvw")
+}
+
+// Utility type for marking CSS values as large viewport height (adds a "lvh"
+// suffix).
+type LVH float32
+
+func (value LVH) String() string {
+	return formatFloatUnits(value, "lvh")
+}
+
+// Utility type for marking CSS values as lvmin (adds a "lvmin" suffix).
+type LVMin float32
+
+func (value LVMin) String() string {
+	return formatFloatUnits(value, "lvmin")
+}
+
+// Utility type for marking CSS values as lvmax (adds a "lvmax" suffix).
+type LVMax float32
+
+func (value LVMax) String() string {
+	return formatFloatUnits(value, "lvmax")
+}
+
+// Utility type for marking CSS values as container query width (adds a "cqw"
+// suffix).
+type CQW float32
+
+func (value CQW) String() string {
+	return formatFloatUnits(value, "cqw")
+}
+
+// Utility type for marking CSS values as container query height (adds a "cqh"
+// suffix).
+type CQH float32
+
+func (value CQH) String() string {
+	return formatFloatUnits(value, "cqh")
+}
+
+// Utility type for marking CSS values as container query inline size (adds a
+// "cqi" suffix).
+type CQI float32
+
+func (value CQI) String() string {
+	return formatFloatUnits(value, "cqi")
+}
+
+// Utility type for marking CSS values as container query block size (adds a
+// "cqb" suffix).
+type CQB float32
+
+func (value CQB) String() string {
+	return formatFloatUnits(value, "cqb")
+}
+
+// Utility type for marking CSS values as cqmin (adds a "cqmin" suffix).
+type CQMin float32
+
+func (value CQMin) String() string {
+	return formatFloatUnits(value, "cqmin")
+}
+
+// Utility type for marking CSS values as cqmax (adds a "cqmax" suffix).
+type CQMax float32
+
+func (value CQMax) String() string {
+	return formatFloatUnits(value, "cqmax")
+}
+
+// Utility type for marking CSS values as a fraction of the free space in a grid
+// container (adds a "fr" suffix).
+type FR float32
+
+func (value FR) String() string {
+	return formatFloatUnits(value, "fr")
+}
+
+// Utility type for marking CSS values as an angle in degrees (adds a "deg"
+// suffix).
+type Deg float32
+
+func (value Deg) String() string {
+	return formatFloatUnits(value, "deg")
+}
+
+// Utility type for marking CSS values as an angle in radians (adds a "rad"
+// suffix).
+type Rad float32
+
+func (value Rad) String() string {
+	return formatFloatUnits(value, "rad")
+}
+
+// Utility type for marking CSS values as an angle in gradians (adds a "grad"
+// suffix).
+type Grad float32
+
+func (value Grad) String() string {
+	return formatFloatUnits(value, "grad")
+}
+
+// Utility type for marking CSS values as an angle in turns (adds a "turn"
+// suffix).
+type Turn float32
+
+func (value Turn) String() string {
+	return formatFloatUnits(value, "turn")
+}
+
+// Utility type for marking CSS values as a time in seconds (adds an "s"
+// suffix).
+type Sec float32
+
+func (value Sec) String() string {
+	return formatFloatUnits(value, "s")
+}
+
+// Utility type for marking CSS values as a time in milliseconds (adds a "ms"
+// suffix).
+type MS float32
+
+func (value MS) String() string {
+	return formatFloatUnits(value, "ms")
+}
+
+// Utility type for marking CSS values as a resolution in dots per inch (adds a
+// "dpi" suffix).
+type DPI float32
+
+func (value DPI) String() string {
+	return formatFloatUnits(value, "dpi")
+}
+
+// Utility type for marking CSS values as a resolution in dots per centimeter
+// (adds a "dpcm" suffix).
+type DPCM float32
+
+func (value DPCM) String() string {
+	return formatFloatUnits(value, "dpcm")
+}
+
+// Utility type for marking CSS values as a resolution in dots per pixel (adds a
+// "dppx" suffix).
+type DPPX float32
+
+func (value DPPX) String() string {
+	return formatFloatUnits(value, "dppx")
+}
+
+// Utility type for marking CSS values as unitless numbers, such as for
+// "line-height", "opacity" or "z-index". Unlike a plain int, which is
+// interpreted as pixels by [CssValueToString], a [Num] has no suffix.
+type Num float32
+
+func (value Num) String() string {
+	return formatFloatUnits(value, "")
+}
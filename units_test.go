@@ -27,6 +27,35 @@ func TestCanFormatIntUnits(t *testing.T) {
 		{VMin(5), "5vmin"},
 		{VMax(5), "5vmax"},
 		{Perc(5), "5%"},
+		{DVW(5), "5dvw"},
+		{DVH(5), "5dvh"},
+		{DVMin(5), "5dvmin"},
+		{DVMax(5), "5dvmax"},
+		{SVW(5), "5svw"},
+		{SVH(5), "5svh"},
+		{SVMin(5), "5svmin"},
+		{SVMax(5), "5svmax"},
+		{LVW(5), "5lvw"},
+		{LVH(5), "5lvh"},
+		{LVMin(5), "5lvmin"},
+		{LVMax(5), "5lvmax"},
+		{CQW(5), "5cqw"},
+		{CQH(5), "5cqh"},
+		{CQI(5), "5cqi"},
+		{CQB(5), "5cqb"},
+		{CQMin(5), "5cqmin"},
+		{CQMax(5), "5cqmax"},
+		{FR(5), "5fr"},
+		{Deg(5), "5deg"},
+		{Rad(5), "5rad"},
+		{Grad(5), "5grad"},
+		{Turn(5), "5turn"},
+		{Sec(5), "5s"},
+		{MS(5), "5ms"},
+		{DPI(5), "5dpi"},
+		{DPCM(5), "5dpcm"},
+		{DPPX(5), "5dppx"},
+		{Num(5), "5"},
 	}
 
 	for _, testCase := range tests {
@@ -46,3 +75,17 @@ func TestFloatUnitsAreTruncatedTo2DP(t *testing.T) {
 	formatted := value.String()
 	assertEqual(t, "3.14em", formatted)
 }
+
+func TestUnitlessNumbersHaveNoSuffix(t *testing.T) {
+	value := Num(1.5)
+	formatted := value.String()
+	assertEqual(t, "1.50", formatted)
+}
+
+func TestCanChangeUnitPrecision(t *testing.T) {
+	defer func(precision int) { UnitPrecision = precision }(UnitPrecision)
+	UnitPrecision = 4
+	value := EM(3.14159)
+	formatted := value.String()
+	assertEqual(t, "3.1416em", formatted)
+}